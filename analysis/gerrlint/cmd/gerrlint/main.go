@@ -0,0 +1,15 @@
+// Command gerrlint runs the gerrlint analyzers, either on its own or as a vet tool:
+//
+//	go install github.com/insan1k/gerr/analysis/gerrlint/cmd/gerrlint
+//	go vet -vettool=$(which gerrlint) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"github.com/insan1k/gerr/analysis/gerrlint"
+)
+
+func main() {
+	multichecker.Main(gerrlint.Analyzers...)
+}
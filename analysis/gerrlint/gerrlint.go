@@ -0,0 +1,360 @@
+// Package gerrlint provides analyzers reporting the misuses of the gerr package, such as comparing Grr values with ==,
+// discarding the Grr returned by Add, asserting the type of the result of AsGrr without checking it, or changing the
+// global separator outside of an init function. The analyzers can be run with go vet, see the gerrlint command.
+package gerrlint
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// gerrPath is the import path of the gerr package
+const gerrPath = "github.com/insan1k/gerr"
+
+// Analyzers holds all the analyzers of the suite
+var Analyzers = []*analysis.Analyzer{
+	CompareAnalyzer,
+	DiscardAnalyzer,
+	AssertAnalyzer,
+	SeparatorAnalyzer,
+}
+
+// CompareAnalyzer reports the comparisons of Grr values with == and !=, which compare the values of the package types
+// instead of matching the kind and the chain as Is does, the suggested fix replaces them with Is
+var CompareAnalyzer = &analysis.Analyzer{
+	Name:     "grrcompare",
+	Doc:      "report comparisons of gerr.Grr values with == and !=, use Is instead",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runCompare,
+}
+
+// DiscardAnalyzer reports the calls to the methods of Grr returning a new Grr whose result is discarded, Grr values are
+// immutable so the call has no effect, the suggested fix assigns the result back to the receiver
+var DiscardAnalyzer = &analysis.Analyzer{
+	Name:     "grrdiscard",
+	Doc:      "report discarded results of gerr.Grr methods, Grr values are immutable",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runDiscard,
+}
+
+// AssertAnalyzer reports the single value type assertions on the result of gerr.AsGrr, which panic whenever the error
+// is not of the asserted type, the suggested fix checks the assertion instead when it defines a variable
+var AssertAnalyzer = &analysis.Analyzer{
+	Name:     "grrassert",
+	Doc:      "report unchecked type assertions on the result of gerr.AsGrr",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runAssert,
+}
+
+// SeparatorAnalyzer reports the calls to the functions changing the global separator outside of init functions and
+// tests, changing it while errors are created affects the errors of every package, the suggested fix is to configure
+// the separator per error with gerr.WithSeparator or gerr.Config
+var SeparatorAnalyzer = &analysis.Analyzer{
+	Name:     "grrseparator",
+	Doc:      "report changes of the gerr global separator outside of init functions",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runSeparator,
+}
+
+// separatorSetters are the functions of the gerr package changing the global separator
+var separatorSetters = map[string]bool{
+	"SetCustomSeparator":        true,
+	"SetColonSeparator":         true,
+	"SetColonAndSpaceSeparator": true,
+	"SetSpaceSeparator":         true,
+}
+
+// immutableMethods are the methods of Grr returning a new Grr instead of modifying the receiver
+var immutableMethods = map[string]bool{
+	"Add":        true,
+	"Append":     true,
+	"Sanitize":   true,
+	"SanitizeTo": true,
+}
+
+func runCompare(pass *analysis.Pass) (interface{}, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.Preorder([]ast.Node{(*ast.BinaryExpr)(nil)}, func(n ast.Node) {
+		be := n.(*ast.BinaryExpr)
+		if be.Op != token.EQL && be.Op != token.NEQ {
+			return
+		}
+		if isNil(pass, be.X) || isNil(pass, be.Y) {
+			return
+		}
+		recv, target := be.X, be.Y
+		if !isGrr(pass.TypesInfo.TypeOf(recv)) {
+			recv, target = target, recv
+		}
+		if !isGrr(pass.TypesInfo.TypeOf(recv)) {
+			return
+		}
+		fix := render(pass.Fset, recv) + ".Is(" + render(pass.Fset, target) + ")"
+		if be.Op == token.NEQ {
+			fix = "!" + fix
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:     be.Pos(),
+			End:     be.End(),
+			Message: "gerr.Grr compared with " + be.Op.String() + ", use Is to match the kind and the chain",
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   "Use Is",
+				TextEdits: []analysis.TextEdit{{Pos: be.Pos(), End: be.End(), NewText: []byte(fix)}},
+			}},
+		})
+	})
+	return nil, nil
+}
+
+func runDiscard(pass *analysis.Pass) (interface{}, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.Preorder([]ast.Node{(*ast.ExprStmt)(nil)}, func(n ast.Node) {
+		stmt := n.(*ast.ExprStmt)
+		call, ok := stmt.X.(*ast.CallExpr)
+		if !ok {
+			return
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !immutableMethods[sel.Sel.Name] {
+			return
+		}
+		if s := pass.TypesInfo.Selections[sel]; s == nil || s.Kind() != types.MethodVal {
+			return
+		}
+		if !isGrr(pass.TypesInfo.TypeOf(call)) {
+			return
+		}
+		d := analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: "result of " + sel.Sel.Name + " is discarded, gerr.Grr values are immutable",
+		}
+		if isAssignable(sel.X) && isGrr(pass.TypesInfo.TypeOf(sel.X)) {
+			d.SuggestedFixes = []analysis.SuggestedFix{{
+				Message: "Assign the result to " + render(pass.Fset, sel.X),
+				TextEdits: []analysis.TextEdit{{
+					Pos:     stmt.Pos(),
+					End:     stmt.Pos(),
+					NewText: []byte(render(pass.Fset, sel.X) + " = "),
+				}},
+			}}
+		}
+		pass.Report(d)
+	})
+	return nil, nil
+}
+
+func runAssert(pass *analysis.Pass) (interface{}, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.WithStack([]ast.Node{(*ast.TypeAssertExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		ta := n.(*ast.TypeAssertExpr)
+		if !push || ta.Type == nil || !isAsGrrCall(pass, ta.X) {
+			return true
+		}
+		d := analysis.Diagnostic{
+			Pos:     ta.Pos(),
+			End:     ta.End(),
+			Message: "unchecked type assertion on the result of gerr.AsGrr panics when the error is of another type",
+		}
+		switch parent := stack[len(stack)-2].(type) {
+		case *ast.AssignStmt:
+			if len(parent.Lhs) == 2 {
+				return true
+			}
+			if fix, ok := checkedAssert(pass, parent, ta, stack); ok {
+				d.SuggestedFixes = []analysis.SuggestedFix{fix}
+			}
+		case *ast.ValueSpec:
+			if len(parent.Names) == 2 {
+				return true
+			}
+		}
+		pass.Report(d)
+		return true
+	})
+	return nil, nil
+}
+
+// checkedAssert returns the suggested fix checking the type assertion defining a single variable, the fix returns the
+// error from the enclosing function when the assertion fails, it is only available when the last result of the
+// function is an error and the remaining ones have zero values that can be written as literals
+func checkedAssert(pass *analysis.Pass, as *ast.AssignStmt, ta *ast.TypeAssertExpr, stack []ast.Node) (analysis.SuggestedFix, bool) {
+	if len(as.Lhs) != 1 || as.Tok != token.DEFINE {
+		return analysis.SuggestedFix{}, false
+	}
+	results := errorResults(pass, stack)
+	if results == "" {
+		return analysis.SuggestedFix{}, false
+	}
+	arg := render(pass.Fset, astutil.Unparen(ta.X).(*ast.CallExpr).Args[0])
+	fix := render(pass.Fset, as.Lhs[0]) + ", ok := " + render(pass.Fset, ta) + "\n" +
+		"if !ok {\n\treturn " + strings.Replace(results, "%s", arg, 1) + "\n}"
+	return analysis.SuggestedFix{
+		Message:   "Check the type assertion",
+		TextEdits: []analysis.TextEdit{{Pos: as.Pos(), End: as.End(), NewText: []byte(fix)}},
+	}, true
+}
+
+// errorResults returns the results of a return statement of the function enclosing the given stack, with %s standing
+// for the error, when the last result of the function is an error and the remaining ones can be returned as zero values
+func errorResults(pass *analysis.Pass, stack []ast.Node) string {
+	var ft *ast.FuncType
+	for i := len(stack) - 1; i >= 0 && ft == nil; i-- {
+		switch f := stack[i].(type) {
+		case *ast.FuncDecl:
+			ft = f.Type
+		case *ast.FuncLit:
+			ft = f.Type
+		}
+	}
+	if ft == nil || ft.Results == nil {
+		return ""
+	}
+	var results []string
+	for _, field := range ft.Results.List {
+		t := pass.TypesInfo.TypeOf(field.Type)
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			results = append(results, zeroValue(t))
+		}
+	}
+	last := pass.TypesInfo.TypeOf(ft.Results.List[len(ft.Results.List)-1].Type)
+	if !types.Identical(last, types.Universe.Lookup("error").Type()) {
+		return ""
+	}
+	results[len(results)-1] = "%s"
+	for _, r := range results {
+		if r == "" {
+			return ""
+		}
+	}
+	return strings.Join(results, ", ")
+}
+
+// zeroValue returns the literal of the zero value of the given type, or an empty string when it has none that can be
+// written without qualifying the type
+func zeroValue(t types.Type) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "false"
+		case u.Info()&types.IsNumeric != 0:
+			return "0"
+		case u.Info()&types.IsString != 0:
+			return `""`
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return "nil"
+	}
+	return ""
+}
+
+func runSeparator(pass *analysis.Pass) (interface{}, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		fn := calledFunc(pass, call)
+		if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != gerrPath || !separatorSetters[fn.Name()] {
+			return true
+		}
+		if inInit(stack) || strings.HasSuffix(pass.Fset.Position(call.Pos()).Filename, "_test.go") {
+			return true
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: "gerr." + fn.Name() + " changes the separator of every package, call it in an init function or use gerr.WithSeparator or gerr.Config",
+		})
+		return true
+	})
+	return nil, nil
+}
+
+// inInit reports whether the given stack is inside a package init function
+func inInit(stack []ast.Node) bool {
+	for _, n := range stack {
+		if fd, ok := n.(*ast.FuncDecl); ok {
+			return fd.Recv == nil && fd.Name.Name == "init"
+		}
+	}
+	return false
+}
+
+// isGrr reports whether the given type is the gerr.Grr interface
+func isGrr(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == gerrPath && obj.Name() == "Grr"
+}
+
+// isNil reports whether the expression is the predeclared nil
+func isNil(pass *analysis.Pass, e ast.Expr) bool {
+	tv, ok := pass.TypesInfo.Types[e]
+	return ok && tv.IsNil()
+}
+
+// isAsGrrCall reports whether the expression is a call to gerr.AsGrr
+func isAsGrrCall(pass *analysis.Pass, e ast.Expr) bool {
+	call, ok := astutil.Unparen(e).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn := calledFunc(pass, call)
+	return fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == gerrPath && fn.Name() == "AsGrr"
+}
+
+// calledFunc returns the package level function called, or nil when the call is not a call to one
+func calledFunc(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
+	var id *ast.Ident
+	switch f := astutil.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = f
+	case *ast.SelectorExpr:
+		id = f.Sel
+	default:
+		return nil
+	}
+	fn, ok := pass.TypesInfo.Uses[id].(*types.Func)
+	if !ok || fn.Type().(*types.Signature).Recv() != nil {
+		return nil
+	}
+	return fn
+}
+
+// isAssignable reports whether the expression can be assigned to without side effects, which is the case for
+// identifiers and selectors of identifiers
+func isAssignable(e ast.Expr) bool {
+	switch e := e.(type) {
+	case *ast.Ident:
+		return e.Name != "_"
+	case *ast.SelectorExpr:
+		return isAssignable(e.X)
+	}
+	return false
+}
+
+// render returns the source code of the given node
+func render(fset *token.FileSet, n ast.Node) string {
+	var buf bytes.Buffer
+	_ = format.Node(&buf, fset, n)
+	return buf.String()
+}
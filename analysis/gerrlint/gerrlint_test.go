@@ -0,0 +1,26 @@
+package gerrlint
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzers(t *testing.T) {
+	tests := []struct {
+		name     string
+		analyzer *analysis.Analyzer
+		pkg      string
+	}{
+		{name: "Compare", analyzer: CompareAnalyzer, pkg: "compare"},
+		{name: "Discard", analyzer: DiscardAnalyzer, pkg: "discard"},
+		{name: "Assert", analyzer: AssertAnalyzer, pkg: "assert"},
+		{name: "Separator", analyzer: SeparatorAnalyzer, pkg: "separator"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), tt.analyzer, tt.pkg)
+		})
+	}
+}
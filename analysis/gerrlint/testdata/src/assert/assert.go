@@ -0,0 +1,34 @@
+package assert
+
+import (
+	"github.com/insan1k/gerr"
+)
+
+type LocalError struct {
+	gerr.Grr
+	user string
+}
+
+func user(err error) (string, error) {
+	l := gerr.AsGrr(err).(*LocalError) // want `unchecked type assertion on the result of gerr.AsGrr panics when the error is of another type`
+	return l.user, nil
+}
+
+func userChecked(err error) string {
+	if l, ok := gerr.AsGrr(err).(*LocalError); ok {
+		return l.user
+	}
+	var l, ok = gerr.AsGrr(err).(*LocalError)
+	if ok {
+		return l.user
+	}
+	switch l := gerr.AsGrr(err).(type) {
+	case *LocalError:
+		return l.user
+	}
+	return ""
+}
+
+func userNoFix(err error) string {
+	return gerr.AsGrr(err).(*LocalError).user // want `unchecked type assertion on the result of gerr.AsGrr panics when the error is of another type`
+}
@@ -0,0 +1,25 @@
+package compare
+
+import (
+	"errors"
+
+	"github.com/insan1k/gerr"
+)
+
+var errRead = errors.New("failed to read")
+
+func compare(g gerr.Grr, err error) bool {
+	if g == nil || nil != g {
+		return false
+	}
+	if err == errRead {
+		return true
+	}
+	if g == errRead { // want `gerr.Grr compared with ==, use Is to match the kind and the chain`
+		return true
+	}
+	if err != g { // want `gerr.Grr compared with !=, use Is to match the kind and the chain`
+		return false
+	}
+	return gerr.New(errRead) == err // want `gerr.Grr compared with ==, use Is to match the kind and the chain`
+}
@@ -0,0 +1,21 @@
+package discard
+
+import (
+	"errors"
+
+	"github.com/insan1k/gerr"
+)
+
+type holder struct {
+	err gerr.Grr
+}
+
+func discard(g gerr.Grr, h *holder) gerr.Grr {
+	g.Add(errors.New("two"))                  // want `result of Add is discarded, gerr.Grr values are immutable`
+	h.err.Append(errors.New("three"))         // want `result of Append is discarded, gerr.Grr values are immutable`
+	g.Sanitize()                              // want `result of Sanitize is discarded, gerr.Grr values are immutable`
+	gerr.New(errors.New("one")).SanitizeTo(0) // want `result of SanitizeTo is discarded, gerr.Grr values are immutable`
+	g = g.Add(errors.New("four"))
+	_ = g.Is(errors.New("four"))
+	return g
+}
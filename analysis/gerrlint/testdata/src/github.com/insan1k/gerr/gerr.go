@@ -0,0 +1,29 @@
+// Package gerr is a stub of the gerr package holding the API checked by the analyzers
+package gerr
+
+type Visibility int
+
+type Grr interface {
+	Add(err error, v ...Visibility) Grr
+	Append(errs ...error) Grr
+	Error() string
+	Is(err error) bool
+	Sanitize() Grr
+	SanitizeTo(audience Visibility) Grr
+}
+
+type Option func()
+
+func New(kind error, opts ...Option) Grr { return nil }
+
+func AsGrr(err error) Grr { return nil }
+
+func SetCustomSeparator(s string) {}
+
+func SetColonSeparator() {}
+
+func SetColonAndSpaceSeparator() {}
+
+func SetSpaceSeparator() {}
+
+func SetStringMatching() {}
@@ -0,0 +1,17 @@
+package separator
+
+import (
+	"github.com/insan1k/gerr"
+)
+
+func init() {
+	gerr.SetColonAndSpaceSeparator()
+}
+
+func configure() {
+	gerr.SetCustomSeparator(" | ") // want `gerr.SetCustomSeparator changes the separator of every package, call it in an init function or use gerr.WithSeparator or gerr.Config`
+	gerr.SetStringMatching()
+	func() {
+		gerr.SetColonSeparator() // want `gerr.SetColonSeparator changes the separator of every package, call it in an init function or use gerr.WithSeparator or gerr.Config`
+	}()
+}
@@ -0,0 +1,11 @@
+package separator
+
+import (
+	"testing"
+
+	"github.com/insan1k/gerr"
+)
+
+func TestSeparator(t *testing.T) {
+	gerr.SetSpaceSeparator()
+}
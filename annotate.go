@@ -0,0 +1,67 @@
+package gerr
+
+import "fmt"
+
+// Annotate makes the given kind the kind of the error pointed to by err, and applies the given options to it, when the
+// error is not nil, so that every return path of a function with a named error result is annotated by a single
+// deferred call. The error is converted as AsGrr does, its kind and its chain are pushed below the given kind as
+// internal entries, so that the annotated error is sanitized down to the given kind and returns its code, the fields,
+// the configuration and the stack trace of the error are kept, while every branch of a joined error, as built by Join or
+// errors.Join, is annotated.
+//
+//	func readGroup(name string) (err error) {
+//		defer gerr.Annotate(&err, ErrFailedToRead, gerr.WithField("group", name))
+//		...
+//	}
+func Annotate(err *error, kind error, opts ...Option) {
+	if err == nil || *err == nil || kind == nil {
+		return
+	}
+	if branches, ok := DefaultConfig().branches(*err); ok {
+		errs := make([]Grr, len(branches))
+		for i, g := range branches {
+			branch := error(g)
+			Annotate(&branch, kind, opts...)
+			errs[i] = branch.(Grr)
+		}
+		*err = newJoined(errs)
+		return
+	}
+	*err = fire(hookAdd, annotate(DefaultConfig(), *err, kind, opts))
+}
+
+// annotate returns the error converted to the package type Wrapped with the given kind as its kind, the kind and the
+// chain of the error are pushed below the chain of the given kind, the options are applied last, the add hooks are left
+// to the caller
+func annotate(c Config, err, kind error, opts []Option) wrapped {
+	w := newWrapped(c, err)
+	a := newWrappedFromWrappedError(kind, w.kind)
+	if _, ok := kind.(Grr); ok {
+		fields := w.kind.ext().fields
+		a.kind = a.kind.withExtras(func(e *kindExtras) {
+			e.fields = mergeFields(fields, e.fields)
+		})
+	}
+	if a.kind.stack == nil {
+		a.kind.stack = w.kind.stack
+	}
+	a.err = appendChain(a.err, w.push(), a.kind.separator)
+	a, _ = a.apply(opts)
+	return a
+}
+
+// Wrapf adds the error built from the given format and arguments to the chain of the error pointed to by err when it is
+// not nil, the error is converted as AsGrr does and keeps its kind, the added entry is internal, so that the formatted
+// message is removed when the error is sanitized, every branch of a joined Grr has the entry added, see Annotate to
+// replace the kind instead
+//
+//	func readGroup(name string) (err error) {
+//		defer gerr.Wrapf(&err, "cannot read group %s", name)
+//		...
+//	}
+func Wrapf(err *error, format string, a ...interface{}) {
+	if err == nil || *err == nil {
+		return
+	}
+	*err = fire(hookAdd, addGrr(DefaultConfig().asGrr(*err), fmt.Errorf(format, a...), nil))
+}
@@ -0,0 +1,170 @@
+package gerr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var (
+	errAnnotateRead = DefineKind("annotate.read", errors.New("failed to read"))
+	errAnnotateDB   = errors.New("fake DB error")
+)
+
+func annotated(err error, group string) (ret error) {
+	defer Annotate(&ret, errAnnotateRead, WithField("group", group))
+	return err
+}
+
+func wrappedf(err error, group string) (ret error) {
+	defer Wrapf(&ret, "cannot read group %s", group)
+	return err
+}
+
+func TestAnnotate(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantChain  []string
+		wantFields []Field
+	}{
+		{
+			name:       "Foreign",
+			err:        errAnnotateDB,
+			wantChain:  []string{"failed to read", "fake DB error"},
+			wantFields: []Field{{Key: "group", Value: "someGroup"}},
+		},
+		{
+			name:       "Kind",
+			err:        New(errAnnotateDB),
+			wantChain:  []string{"failed to read", "fake DB error"},
+			wantFields: []Field{{Key: "group", Value: "someGroup"}},
+		},
+		{
+			name:       "Wrapped",
+			err:        New(errAnnotateDB, WithField("id", 1)).Add(errors.New("timeout")),
+			wantChain:  []string{"failed to read", "fake DB error", "timeout"},
+			wantFields: []Field{{Key: "id", Value: 1}, {Key: "group", Value: "someGroup"}},
+		},
+		{
+			name:       "ForeignWrapper",
+			err:        fmt.Errorf("querying: %w", errAnnotateDB),
+			wantChain:  []string{"failed to read", "querying:", "fake DB error"},
+			wantFields: []Field{{Key: "group", Value: "someGroup"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AsGrr(annotated(tt.err, "someGroup"))
+			var chain []string
+			for _, e := range got.Chain() {
+				chain = append(chain, e.Error())
+			}
+			if !reflect.DeepEqual(chain, tt.wantChain) {
+				t.Errorf("Annotate() chain = %q, want %q", chain, tt.wantChain)
+			}
+			if !reflect.DeepEqual(got.Fields(), tt.wantFields) {
+				t.Errorf("Annotate() fields = %v, want %v", got.Fields(), tt.wantFields)
+			}
+			if !got.Is(errAnnotateRead) || !got.Is(errAnnotateDB) {
+				t.Errorf("Annotate() = %v, want it to match both %v and %v", got, errAnnotateRead, errAnnotateDB)
+			}
+		})
+	}
+}
+
+func TestAnnotateNil(t *testing.T) {
+	if err := annotated(nil, "someGroup"); err != nil {
+		t.Errorf("Annotate() = %v, want nil", err)
+	}
+	if err := wrappedf(nil, "someGroup"); err != nil {
+		t.Errorf("Wrapf() = %v, want nil", err)
+	}
+	Annotate(nil, errAnnotateRead)
+	Wrapf(nil, "cannot read group %s", "someGroup")
+}
+
+func TestAnnotateJoined(t *testing.T) {
+	for _, err := range []error{
+		annotated(Join(errAnnotateDB, errors.New("timeout")), "someGroup"),
+		annotated(errors.Join(errAnnotateDB, errors.New("timeout")), "someGroup"),
+	} {
+		assertAnnotatedJoined(t, err)
+	}
+}
+
+func assertAnnotatedJoined(t *testing.T, err error) {
+	t.Helper()
+	j, ok := err.(joined)
+	if !ok || len(j.errs) != 2 {
+		t.Fatalf("Annotate() = %#v, want a joined error with 2 branches", err)
+	}
+	for _, g := range j.errs {
+		if !g.Is(errAnnotateRead) {
+			t.Errorf("Annotate() branch = %v, want it to match %v", g, errAnnotateRead)
+		}
+	}
+	if want := "failed to read fake DB error\nfailed to read timeout"; err.Error() != want {
+		t.Errorf("Annotate() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestWrapf(t *testing.T) {
+	err := wrappedf(New(errAnnotateRead).Add(errAnnotateDB), "someGroup")
+	if want := "failed to read cannot read group someGroup fake DB error"; err.Error() != want {
+		t.Errorf("Wrapf() = %q, want %q", err.Error(), want)
+	}
+	if !AsGrr(err).Is(errAnnotateDB) {
+		t.Errorf("Wrapf() = %v, want it to match %v", err, errAnnotateDB)
+	}
+}
+
+func TestAnnotateSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "Foreign",
+			err:  errors.New("sql://someUser@ConnectionString:10005 refused"),
+		},
+		{
+			name: "Kind",
+			err:  New(errors.New("sql://someUser@ConnectionString:10005 refused"), WithPublicField("id", 1)),
+		},
+		{
+			name: "Wrapped",
+			err:  New(errAnnotateDB).Add(errors.New("sql://someUser@ConnectionString:10005 refused")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AsGrr(annotated(tt.err, "someGroup"))
+			if got.Code() != "annotate.read" {
+				t.Errorf("Annotate() code = %q, want %q", got.Code(), "annotate.read")
+			}
+			sanitized := got.Sanitize()
+			if !sanitized.Is(errAnnotateRead) {
+				t.Errorf("Annotate() sanitized = %v, want it to match %v", sanitized, errAnnotateRead)
+			}
+			if strings.Contains(sanitized.Error(), "ConnectionString") {
+				t.Errorf("Annotate() sanitized = %q, want the connection string removed", sanitized.Error())
+			}
+		})
+	}
+}
+
+func TestWrapfKeepsKind(t *testing.T) {
+	got := AsGrr(wrappedf(New(errAnnotateRead), "secretGroup"))
+	if got.Code() != "annotate.read" {
+		t.Errorf("Wrapf() code = %q, want %q", got.Code(), "annotate.read")
+	}
+	if want := "failed to read"; got.Sanitize().Error() != want {
+		t.Errorf("Wrapf() sanitized = %q, want %q", got.Sanitize().Error(), want)
+	}
+	if want := "failed to read cannot read group secretGroup"; got.Error() != want {
+		t.Errorf("Wrapf() = %q, want %q", got.Error(), want)
+	}
+}
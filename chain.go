@@ -38,59 +38,227 @@ func WithBottomFirst() SanitizeOpt {
 // SanitizeOpt is the functional type for configuring the sanitization taking place in the Chain function
 type SanitizeOpt func(c *sanitizeConfig)
 
+// WithBreadthFirst configures the chain of errors that wrap multiple errors to be built level by level, by default the
+// chain is built depth first, where each wrapped error is followed by its own chain before moving to the next one
+func WithBreadthFirst() SanitizeOpt {
+	return func(c *sanitizeConfig) {
+		c.breadthFirst = true
+	}
+}
+
 // Chain builds and returns the error chain as a slice of errors, note that for this function to work as intended the
-// the error must be wrapped according to the go 1.13 error wrapping guidelines
+// the error must be wrapped according to the go 1.13 error wrapping guidelines, the layers built by this package are
+// returned as their original error values. Errors that wrap multiple errors, as errors.Join does, are flattened into
+// the chain in the order given by the traversal configured
 func Chain(err error, opts ...SanitizeOpt) []error {
 	c := sanitizeConfig{}
 	for _, opt := range opts {
 		opt(&c)
 	}
-	if u, ok := err.(interface {
-		Unwrap() error
-	}); ok {
+	if isWrapper(err) {
 		var chain []error
-		currentErr := err
-		nextErr := u.Unwrap()
-		hasNext := true
-		for hasNext {
-			currentErr, nextErr, hasNext = unwrapBuildChain(currentErr, nextErr, &chain, c)
-		}
+		walk(err, c, func(err error, next []error) {
+			appendOwn(err, next, &chain, c)
+		})
 		if c.bottomFirst {
 			reverse[[]error, error](chain)
 		}
 		return chain
 	}
+	if v, ok := ownValue(err); ok {
+		return []error{v}
+	}
 	return []error{errors.New(sanitizeString(err.Error(), c))}
 }
 
-// unwrapBuildChain unwraps the error chain and builds it as a slice of errors returns the current error, the next error
-// and a boolean indicating if there are more errors to continue
-func unwrapBuildChain(currentErr error, nextErr error, chain *[]error, conf sanitizeConfig) (error, error, bool) {
-	current, currOk := currentErr.(interface {
-		Unwrap() error
-	})
-	next, nextOk := nextErr.(interface {
-		Unwrap() error
+// Layer is an entry of the structural chain returned by Layers
+type Layer struct {
+	// Message is the message of the layer alone
+	Message string
+	// Original is the error value of the layer, for foreign wrappers it is the wrapper itself
+	Original error
+	// Exact is set when the message was recorded when the layer was built by this package, or when it was extracted
+	// from a foreign error whose message starts or ends with the message of the error it wraps, otherwise the message
+	// is a best effort guess
+	Exact bool
+}
+
+// Layers builds and returns the structural error chain, unlike Chain the layers built by this package report the
+// message they were built with, while the messages of foreign errors are extracted by matching the message of the
+// error they wrap as a suffix, as fmt.Errorf("context: %w") does, or as a prefix, as fmt.Errorf("%w: context") does,
+// the surrounding spaces and punctuation left by the wrapping are trimmed from them. The messages of a Grr and of the
+// entries of its chain are redacted by the rule of the Grr, see RedactRule. The traversal and the order of the layers
+// are configured the same way as for Chain.
+func Layers(err error, opts ...SanitizeOpt) []Layer {
+	if err == nil {
+		return nil
+	}
+	c := sanitizeConfig{}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	var layers []Layer
+	// redact is the rule of the last Grr walked, the entries of its chain are walked right after it
+	var redact RedactRule
+	walk(err, c, func(err error, next []error) {
+		l, ok := ownLayer(err, next, c)
+		if !ok {
+			return
+		}
+		switch e := err.(type) {
+		case kind:
+			redact = e.ext().redact
+		case wrapped:
+			redact = e.kind.ext().redact
+		default:
+			l.Message = redact.redacted(l.Message)
+		}
+		layers = append(layers, l)
 	})
-	if currOk && nextOk {
-		sanitizeAppend(currentErr, nextErr, chain, conf)
-		return current.Unwrap(), next.Unwrap(), true
+	if c.bottomFirst {
+		reverse[[]Layer, Layer](layers)
+	}
+	return layers
+}
+
+// ownLayer returns the part of the error that is its own as a Layer, the second value reports whether the error has a
+// part of its own
+func ownLayer(err error, next []error, conf sanitizeConfig) (Layer, bool) {
+	switch e := err.(type) {
+	case kind:
+		return e.layer(), true
+	case wrapped:
+		return e.kind.layer(), true
+	case layer:
+		return e.layer(), true
+	case joined:
+		return Layer{}, false
+	}
+	var s string
+	var exact bool
+	if len(next) > 1 {
+		s, exact = ownMessages(err, next)
+	} else {
+		var nextErr error
+		if len(next) == 1 {
+			nextErr = next[0]
+		}
+		s, exact = ownMessage(err, nextErr)
+	}
+	s = sanitizeString(strings.Trim(s, wrappingCutset), conf)
+	if s == "" {
+		return Layer{}, false
+	}
+	return Layer{Message: s, Original: err, Exact: exact}, true
+}
+
+// wrappingCutset holds the characters commonly left around the message of a foreign wrapper once the message of the
+// error it wraps is removed
+const wrappingCutset = " \t\n:;,"
+
+// walk visits the given error and the errors it wraps in the order configured, the errors wrapped by each visited
+// error are given along with it
+func walk(err error, conf sanitizeConfig, visit func(err error, next []error)) {
+	if conf.breadthFirst {
+		walkBreadthFirst(err, visit)
+		return
+	}
+	walkDepthFirst(err, visit)
+}
+
+// walkDepthFirst visits the given error followed by the chain of each of the errors it wraps
+func walkDepthFirst(err error, visit func(err error, next []error)) {
+	for err != nil {
+		next := unwrapAll(err)
+		visit(err, next)
+		if len(next) == 1 {
+			err = next[0]
+			continue
+		}
+		for _, n := range next {
+			walkDepthFirst(n, visit)
+		}
+		return
 	}
-	if currOk && !nextOk && nextErr != nil {
-		sanitizeAppend(currentErr, nextErr, chain, conf)
-		*chain = append(*chain, errors.New(sanitizeString(nextErr.Error(), conf)))
+}
+
+// walkBreadthFirst visits the given error followed by the errors it wraps, level by level
+func walkBreadthFirst(err error, visit func(err error, next []error)) {
+	queue := []error{err}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		next := unwrapAll(current)
+		visit(current, next)
+		queue = append(queue, next...)
 	}
-	return nil, nil, false
+}
+
+// appendOwn appends the part of the error that is its own to the chain, package types contribute their original error
+// values, while for other errors the strings of the errors they wrap are removed from their string
+func appendOwn(err error, next []error, chain *[]error, conf sanitizeConfig) {
+	if v, ok := ownValue(err); ok {
+		*chain = append(*chain, v)
+		return
+	}
+	if _, ok := err.(interface {
+		Unwrap() []error
+	}); ok {
+		// the spaces left around the removed errors are collapsed, as they are for the layers split from such errors
+		s := strings.Join(strings.Fields(removeEqualPartsFromError(err, next)), " ")
+		if s != "" {
+			*chain = append(*chain, errors.New(sanitizeString(s, conf)))
+		}
+		return
+	}
+	var nextErr error
+	if len(next) > 0 {
+		nextErr = next[0]
+	}
+	sanitizeAppend(err, nextErr, chain, conf)
 }
 
 // sanitizeAppend removes the string of the next error from the current error and appends it if the result is not an
-// empty string, this is done to support poorly implemented error wrapping
+// empty string, this is done to support poorly implemented error wrapping, the current error is appended as is when
+// its string is left untouched
 func sanitizeAppend(currentErr, nextErr error, chain *[]error, conf sanitizeConfig) {
 	s := removeEqualPartFromError(currentErr, nextErr)
-	if s != "" {
-		s = sanitizeString(s, conf)
-		*chain = append(*chain, errors.New(s))
+	if s == "" {
+		return
+	}
+	if s = sanitizeString(s, conf); s == currentErr.Error() {
+		*chain = append(*chain, currentErr)
+		return
+	}
+	*chain = append(*chain, errors.New(s))
+}
+
+// isWrapper reports whether the error wraps one or multiple errors
+func isWrapper(err error) bool {
+	switch err.(type) {
+	case interface{ Unwrap() error }, interface{ Unwrap() []error }:
+		return true
 	}
+	return false
+}
+
+// unwrapAll returns the errors wrapped by the given error, either a single one or multiple ones
+func unwrapAll(err error) []error {
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		if next := u.Unwrap(); next != nil {
+			return []error{next}
+		}
+	case interface{ Unwrap() []error }:
+		var next []error
+		for _, n := range u.Unwrap() {
+			if n != nil {
+				next = append(next, n)
+			}
+		}
+		return next
+	}
+	return nil
 }
 
 // sanitizeString applies the sanitization options to the given string
@@ -107,15 +275,51 @@ func sanitizeString(s string, conf sanitizeConfig) string {
 	return s
 }
 
-// removeEqualPartFromError removes the string of the next error from the current error
+// removeEqualPartFromError removes the string of the next error from the current error, see ownMessage
 func removeEqualPartFromError(currentErr, nextErr error) string {
+	s, _ := ownMessage(currentErr, nextErr)
+	return s
+}
+
+// ownMessage returns the string of the current error without the string of the next error, which is removed from the
+// end or from the beginning of the string, the second value reports whether it was, when the string of the next error
+// is found elsewhere its last occurrence is removed, and when it is not found the string is returned as is
+func ownMessage(currentErr, nextErr error) (string, bool) {
 	if currentErr == nil {
-		return ""
+		return "", true
 	}
+	s := currentErr.Error()
 	if nextErr == nil {
-		return currentErr.Error()
+		return s, true
+	}
+	n := nextErr.Error()
+	switch {
+	case strings.HasSuffix(s, n):
+		return s[:len(s)-len(n)], true
+	case strings.HasPrefix(s, n):
+		return s[len(n):], true
+	}
+	i := strings.LastIndex(s, n)
+	if i < 0 {
+		return s, false
+	}
+	return s[:i] + s[i+len(n):], false
+}
+
+// ownMessages returns the string of the current error without the strings of each of the wrapped errors, the second
+// value reports whether the remaining string is only made of the characters left by the wrapping, as it is for
+// errors.Join
+func ownMessages(currentErr error, wrappedErrs []error) (string, bool) {
+	s := removeEqualPartsFromError(currentErr, wrappedErrs)
+	return s, strings.Trim(s, wrappingCutset) == ""
+}
+
+// removeEqualPartsFromError removes the string of each of the wrapped errors from the current error
+func removeEqualPartsFromError(currentErr error, wrappedErrs []error) string {
+	s := currentErr.Error()
+	for _, err := range wrappedErrs {
+		s = strings.Replace(s, err.Error(), "", 1)
 	}
-	s := strings.Replace(currentErr.Error(), nextErr.Error(), "", 1)
 	return s
 }
 
@@ -147,4 +351,5 @@ type sanitizeConfig struct {
 	trimColonsFunc func(s string) string
 	trimCustomFunc func(s string) string
 	bottomFirst    bool
+	breadthFirst   bool
 }
@@ -265,6 +265,75 @@ func TestChain(t *testing.T) {
 	}
 }
 
+func TestChainOriginalValues(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	kindErr := errors.New("kind")
+	chain := Chain(New(kindErr).Add(errors.New("original")).Add(sentinel))
+	if len(chain) != 3 {
+		t.Fatalf("Chain() = %v, want 3 errors", chain)
+	}
+	if chain[0] != kindErr {
+		t.Errorf("Chain()[0] = %v, want the original kind error", chain[0])
+	}
+	if chain[1] != sentinel {
+		t.Errorf("Chain()[1] = %v, want the original added error", chain[1])
+	}
+}
+
+func TestChainMultipleErrors(t *testing.T) {
+	a := fmt.Errorf("a: %w", errors.New("a1"))
+	b := fmt.Errorf("b: %w", errors.New("b1"))
+	tests := []struct {
+		name string
+		err  error
+		opts []SanitizeOpt
+		want []string
+	}{
+		{
+			name: "Join",
+			err:  errors.Join(a, b),
+			opts: []SanitizeOpt{WithTrimColons(), WithTrimSpaces()},
+			want: []string{"a", "a1", "b", "b1"},
+		},
+		{
+			name: "JoinBreadthFirst",
+			err:  errors.Join(a, b),
+			opts: []SanitizeOpt{WithTrimColons(), WithTrimSpaces(), WithBreadthFirst()},
+			want: []string{"a", "b", "a1", "b1"},
+		},
+		{
+			name: "JoinBottomFirst",
+			err:  errors.Join(a, b),
+			opts: []SanitizeOpt{WithTrimColons(), WithTrimSpaces(), WithBottomFirst()},
+			want: []string{"b1", "b", "a1", "a"},
+		},
+		{
+			name: "MultipleWrapVerbs",
+			err:  fmt.Errorf("top: %w, %w", a, b),
+			opts: []SanitizeOpt{WithTrimColons(), WithTrimSpaces()},
+			want: []string{"top: ,", "a", "a1", "b", "b1"},
+		},
+		{
+			name: "WrappedJoin",
+			err:  fmt.Errorf("top: %w", errors.Join(a, b)),
+			opts: []SanitizeOpt{WithTrimColons(), WithTrimSpaces()},
+			want: []string{"top", "a", "a1", "b", "b1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := Chain(tt.err, tt.opts...)
+			got := make([]string, len(chain))
+			for i, err := range chain {
+				got[i] = err.Error()
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Chain() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func genWrappedErrWith(count int, sep, pre string) error {
 	if count < 2 {
 		count = 2
@@ -297,3 +366,112 @@ func genWrappedErrWithSpaceAndColon(count int) error {
 func genWrappedErrWithSpaceAndColonAndPrepend(count int) error {
 	return genWrappedErrWith(count, ": ", " :")
 }
+
+func TestLayers(t *testing.T) {
+	sep := _separator()
+	errBottom := errors.New("read failed")
+	suffix := fmt.Errorf("read failed: %w", errBottom)
+	prefix := fmt.Errorf("%w: retrying", errBottom)
+	middle := fmt.Errorf("op [%w] aborted", errBottom)
+	joinedErr := errors.Join(errors.New("one"), errors.New("two"))
+	g := New(errors.New("failed to load"), WithErr(errBottom)).Add(errors.New("cache miss"))
+	redacted := New(errors.New("dial postgres://bob:hunter2@db:5432 failed"), WithRedaction(DefaultRedaction())).
+		Add(errors.New("user bob@example.com"))
+	tests := []struct {
+		name string
+		err  error
+		opts []SanitizeOpt
+		want []Layer
+	}{
+		{
+			name: "Nil",
+		},
+		{
+			name: "Plain",
+			err:  errBottom,
+			want: []Layer{{Message: "read failed", Original: errBottom, Exact: true}},
+		},
+		{
+			name: "RepeatedSuffix",
+			err:  suffix,
+			want: []Layer{
+				{Message: "read failed", Original: suffix, Exact: true},
+				{Message: "read failed", Original: errBottom, Exact: true},
+			},
+		},
+		{
+			name: "Prefix",
+			err:  prefix,
+			want: []Layer{
+				{Message: "retrying", Original: prefix, Exact: true},
+				{Message: "read failed", Original: errBottom, Exact: true},
+			},
+		},
+		{
+			name: "Middle",
+			err:  middle,
+			want: []Layer{
+				{Message: "op [] aborted", Original: middle, Exact: false},
+				{Message: "read failed", Original: errBottom, Exact: true},
+			},
+		},
+		{
+			name: "BottomFirst",
+			err:  prefix,
+			opts: []SanitizeOpt{WithBottomFirst()},
+			want: []Layer{
+				{Message: "read failed", Original: errBottom, Exact: true},
+				{Message: "retrying", Original: prefix, Exact: true},
+			},
+		},
+		{
+			name: "Joined",
+			err:  joinedErr,
+			want: []Layer{
+				{Message: "one", Original: joinedErr.(interface{ Unwrap() []error }).Unwrap()[0], Exact: true},
+				{Message: "two", Original: joinedErr.(interface{ Unwrap() []error }).Unwrap()[1], Exact: true},
+			},
+		},
+		{
+			name: "Grr",
+			err:  g,
+			want: []Layer{
+				{Message: "failed to load", Original: g.(wrapped).kind.err, Exact: true},
+				{Message: "cache miss", Original: g.(wrapped).err.(layer).orig, Exact: true},
+				{Message: "read failed", Original: errBottom, Exact: true},
+			},
+		},
+		{
+			name: "Redacted",
+			err:  redacted,
+			want: []Layer{
+				{Message: "dial postgres://[REDACTED]@db:5432 failed", Original: redacted.(wrapped).kind.err, Exact: true},
+				{Message: "user [EMAIL]", Original: redacted.(wrapped).err, Exact: true},
+			},
+		},
+		{
+			name: "GrrFromForeign",
+			err:  Config{Separator: sep}.New(fmt.Errorf("load: %w", suffix)),
+			want: []Layer{
+				{Message: "load", Original: fmt.Errorf("load: %w", suffix), Exact: true},
+				{Message: "read failed", Original: suffix, Exact: true},
+				{Message: "read failed", Original: errBottom, Exact: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Layers(tt.err, tt.opts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Layers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChainRepeatedMessage(t *testing.T) {
+	err := fmt.Errorf("read failed: %w", errors.New("read failed"))
+	want := []error{errors.New("read failed"), errors.New("read failed")}
+	if got := Chain(err, WithTrimSpaces(), WithTrimColons()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Chain() = %v, want %v", got, want)
+	}
+}
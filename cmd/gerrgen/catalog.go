@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"strings"
+)
+
+// Catalog is the description of the kinds of errors of a package, read from a JSON file
+type Catalog struct {
+	// Package is the name of the package of the generated code
+	Package string `json:"package"`
+	// Type is the name of the integer type the kinds are constants of
+	Type string `json:"type"`
+	// Doc describes the type in the generated code and in the Markdown docs
+	Doc string `json:"doc,omitempty"`
+	// Unknown is the message of the values of the type that are not part of the catalog, "unknown error" by default
+	Unknown string `json:"unknown,omitempty"`
+	// Kinds are the kinds of the catalog, in the order of their constants
+	Kinds []Kind `json:"kinds"`
+}
+
+// Kind is a single kind of a Catalog
+type Kind struct {
+	// Name is the name of the constant of the kind
+	Name string `json:"name"`
+	// Code is the stable code the kind is registered with, see gerr.Register
+	Code string `json:"code"`
+	// Message is the message of the kind
+	Message string `json:"message"`
+	// Doc describes the kind in the generated code and in the Markdown docs
+	Doc string `json:"doc,omitempty"`
+	// HTTP is the HTTP status code the kind maps to, if any
+	HTTP int `json:"http,omitempty"`
+	// Exit is the exit code the kind maps to, if any
+	Exit int `json:"exit,omitempty"`
+}
+
+// readCatalog reads and validates a catalog
+func readCatalog(r io.Reader) (Catalog, error) {
+	var c Catalog
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return Catalog{}, fmt.Errorf("decoding catalog: %w", err)
+	}
+	if c.Unknown == "" {
+		c.Unknown = "unknown error"
+	}
+	return c, c.validate()
+}
+
+// validate reports the first problem of the catalog that would lead to code that does not compile or to duplicate
+// registrations
+func (c Catalog) validate() error {
+	if !token.IsIdentifier(c.Package) {
+		return fmt.Errorf("package %q is not a valid identifier", c.Package)
+	}
+	if !token.IsIdentifier(c.Type) || !token.IsExported(c.Type) {
+		return fmt.Errorf("type %q is not a valid exported identifier", c.Type)
+	}
+	if strings.ContainsAny(c.Doc, "\n") {
+		return fmt.Errorf("type doc must be a single line")
+	}
+	if len(c.Kinds) == 0 {
+		return fmt.Errorf("catalog has no kinds")
+	}
+	names := map[string]bool{}
+	codes := map[string]bool{}
+	for _, k := range c.Kinds {
+		switch {
+		case !token.IsIdentifier(k.Name) || !token.IsExported(k.Name):
+			return fmt.Errorf("kind name %q is not a valid exported identifier", k.Name)
+		case names[k.Name]:
+			return fmt.Errorf("kind name %q is duplicated", k.Name)
+		case k.Code == "":
+			return fmt.Errorf("kind %s has no code", k.Name)
+		case codes[k.Code]:
+			return fmt.Errorf("kind code %q is duplicated", k.Code)
+		case k.Message == "":
+			return fmt.Errorf("kind %s has no message", k.Name)
+		case k.HTTP != 0 && (k.HTTP < 100 || k.HTTP > 599):
+			return fmt.Errorf("kind %s has an invalid HTTP status %d", k.Name, k.HTTP)
+		case strings.ContainsAny(k.Message, "\n"):
+			return fmt.Errorf("kind %s message must be a single line", k.Name)
+		case strings.ContainsAny(k.Doc, "\n"):
+			return fmt.Errorf("kind %s doc must be a single line", k.Name)
+		}
+		names[k.Name] = true
+		codes[k.Code] = true
+	}
+	return nil
+}
+
+// hasHTTP reports whether any kind of the catalog maps to an HTTP status code
+func (c Catalog) hasHTTP() bool {
+	for _, k := range c.Kinds {
+		if k.HTTP != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasExit reports whether any kind of the catalog maps to an exit code
+func (c Catalog) hasExit() bool {
+	for _, k := range c.Kinds {
+		if k.Exit != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// codeTemplate is the template of the generated Go code
+var codeTemplate = template.Must(template.New("code").Funcs(template.FuncMap{
+	"unexported": unexported,
+}).Parse(`// Code generated by gerrgen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- if .HasExit}}
+	"errors"
+{{end}}
+	"github.com/insan1k/gerr"
+{{- if .HasHTTP}}
+	"github.com/insan1k/gerr/httperr"
+{{- end}}
+)
+
+{{with .Doc}}// {{$.Type}} {{.}}{{else}}// {{.Type}} is the kind of the errors of the {{.Package}} package{{end}}
+type {{.Type}} int
+
+const (
+{{- range $i, $k := .Kinds}}
+	// {{$k.Name}} {{with $k.Doc}}{{.}}{{else}}is the kind of the errors with the message "{{$k.Message}}"{{end}}
+	{{$k.Name}}{{if eq $i 0}} {{$.Type}} = iota + 1{{end}}
+{{- end}}
+)
+
+// {{unexported .Type}}Kinds holds the description of each kind of the catalog
+var {{unexported .Type}}Kinds = map[{{.Type}}]struct {
+	message, code string
+	http, exit    int
+}{
+{{- range .Kinds}}
+	{{.Name}}: {message: {{printf "%q" .Message}}, code: {{printf "%q" .Code}}, http: {{.HTTP}}, exit: {{.Exit}}},
+{{- end}}
+}
+
+func init() {
+	for k, d := range {{unexported .Type}}Kinds {
+		if err := gerr.Register(d.code, k); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// String returns the message of the kind
+func (e {{.Type}}) String() string {
+	if d, ok := {{unexported .Type}}Kinds[e]; ok {
+		return d.message
+	}
+	return {{printf "%q" .Unknown}}
+}
+
+// Error implements the error interface
+func (e {{.Type}}) Error() string {
+	return e.String()
+}
+
+// Code returns the code the kind is registered with, or an empty string for the values that are not part of the
+// catalog
+func (e {{.Type}}) Code() string {
+	return {{unexported .Type}}Kinds[e].code
+}
+{{- if .HasHTTP}}
+
+// HTTPStatus returns the HTTP status code the kind maps to, or 0 when it maps to none
+func (e {{.Type}}) HTTPStatus() int {
+	return {{unexported .Type}}Kinds[e].http
+}
+
+// {{.Type}}HTTPOptions returns the options mapping the kinds of the catalog to their HTTP status codes in an
+// httperr.Table
+func {{.Type}}HTTPOptions() []httperr.Option {
+	return []httperr.Option{
+{{- range .Kinds}}{{if .HTTP}}
+		httperr.WithKind({{.Name}}, {{.HTTP}}),
+{{- end}}{{end}}
+	}
+}
+{{- end}}
+{{- if .HasExit}}
+
+// ExitCode returns the exit code the kind maps to, or 0 when it maps to none
+func (e {{.Type}}) ExitCode() int {
+	return {{unexported .Type}}Kinds[e].exit
+}
+
+// {{.Type}}ExitCode returns the exit code of the first kind of the catalog found in the chain of the error, 0 when the
+// error is nil, or 1 when the kind found maps to no exit code or no kind is found
+func {{.Type}}ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var e {{.Type}}
+	if errors.As(err, &e) && e.ExitCode() != 0 {
+		return e.ExitCode()
+	}
+	return 1
+}
+{{- end}}
+`))
+
+// docTemplate is the template of the generated Markdown docs
+var docTemplate = template.Must(template.New("doc").Funcs(template.FuncMap{
+	"cell": cell,
+}).Parse(`<!-- Code generated by gerrgen from {{.Source}}. DO NOT EDIT. -->
+
+# {{.Type}}
+{{with .Doc}}
+{{$.Type}} {{.}}
+{{end}}
+| Name | Code | Message |{{if .HasHTTP}} HTTP |{{end}}{{if .HasExit}} Exit |{{end}} Description |
+|------|------|---------|{{if .HasHTTP}}------|{{end}}{{if .HasExit}}------|{{end}}-------------|
+{{- range .Kinds}}
+| ` + "`{{.Name}}`" + ` | ` + "`{{.Code}}`" + ` | {{cell .Message}} |{{if $.HasHTTP}} {{if .HTTP}}{{.HTTP}}{{end}} |{{end}}{{if $.HasExit}} {{if .Exit}}{{.Exit}}{{end}} |{{end}} {{cell .Doc}} |
+{{- end}}
+`))
+
+// templateData is the data given to the templates
+type templateData struct {
+	Catalog
+	Source  string
+	HasHTTP bool
+	HasExit bool
+}
+
+// newTemplateData returns the data given to the templates for the catalog read from the given source
+func newTemplateData(c Catalog, source string) templateData {
+	return templateData{Catalog: c, Source: source, HasHTTP: c.hasHTTP(), HasExit: c.hasExit()}
+}
+
+// generateCode returns the formatted Go code of the catalog read from the given source
+func generateCode(c Catalog, source string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := codeTemplate.Execute(&buf, newTemplateData(c, source)); err != nil {
+		return nil, err
+	}
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return code, nil
+}
+
+// generateDoc returns the Markdown docs of the catalog read from the given source
+func generateDoc(c Catalog, source string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := docTemplate.Execute(&buf, newTemplateData(c, source)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unexported returns the given identifier with its leading upper case letters lower cased, the last of them is kept
+// when it starts the next word, so that StoreError becomes storeError and HTTPError becomes httpError
+func unexported(s string) string {
+	r := []rune(s)
+	for i := range r {
+		if !unicode.IsUpper(r[i]) || (i > 0 && i+1 < len(r) && unicode.IsLower(r[i+1])) {
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
+
+// cell escapes the given text to be written in a cell of a Markdown table
+func cell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
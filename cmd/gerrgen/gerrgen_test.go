@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerateGolden(t *testing.T) {
+	catalogs, err := filepath.Glob("testdata/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range catalogs {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		t.Run(name, func(t *testing.T) {
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			c, err := readCatalog(f)
+			if err != nil {
+				t.Fatalf("readCatalog() error = %v", err)
+			}
+			code, err := generateCode(c, filepath.Base(path))
+			if err != nil {
+				t.Fatalf("generateCode() error = %v", err)
+			}
+			checkGolden(t, filepath.Join("testdata", name+".go.golden"), code)
+			doc, err := generateDoc(c, filepath.Base(path))
+			if err != nil {
+				t.Fatalf("generateDoc() error = %v", err)
+			}
+			checkGolden(t, filepath.Join("testdata", name+".md.golden"), doc)
+		})
+	}
+}
+
+// checkGolden compares the output with the golden file, which is rewritten instead when the -update flag is set
+func checkGolden(t *testing.T, golden string, got []byte) {
+	t.Helper()
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s, run go test -update to update it\ngot:\n%s\nwant:\n%s", golden, got, want)
+	}
+}
+
+func TestReadCatalogInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		catalog string
+		wantErr string
+	}{
+		{
+			name:    "Malformed",
+			catalog: `{"package": `,
+			wantErr: "decoding catalog",
+		},
+		{
+			name:    "UnknownField",
+			catalog: `{"package": "store", "type": "StoreError", "kinds": [], "typo": 1}`,
+			wantErr: "unknown field",
+		},
+		{
+			name:    "Package",
+			catalog: `{"package": "my-store", "type": "StoreError"}`,
+			wantErr: "package",
+		},
+		{
+			name:    "Type",
+			catalog: `{"package": "store", "type": "storeError"}`,
+			wantErr: "type",
+		},
+		{
+			name:    "NoKinds",
+			catalog: `{"package": "store", "type": "StoreError"}`,
+			wantErr: "no kinds",
+		},
+		{
+			name: "DuplicateName",
+			catalog: `{"package": "store", "type": "StoreError", "kinds": [
+				{"name": "ErrA", "code": "a", "message": "a"}, {"name": "ErrA", "code": "b", "message": "b"}]}`,
+			wantErr: "name \"ErrA\" is duplicated",
+		},
+		{
+			name: "DuplicateCode",
+			catalog: `{"package": "store", "type": "StoreError", "kinds": [
+				{"name": "ErrA", "code": "a", "message": "a"}, {"name": "ErrB", "code": "a", "message": "b"}]}`,
+			wantErr: "code \"a\" is duplicated",
+		},
+		{
+			name:    "NoCode",
+			catalog: `{"package": "store", "type": "StoreError", "kinds": [{"name": "ErrA", "message": "a"}]}`,
+			wantErr: "no code",
+		},
+		{
+			name:    "NoMessage",
+			catalog: `{"package": "store", "type": "StoreError", "kinds": [{"name": "ErrA", "code": "a"}]}`,
+			wantErr: "no message",
+		},
+		{
+			name:    "HTTP",
+			catalog: `{"package": "store", "type": "StoreError", "kinds": [{"name": "ErrA", "code": "a", "message": "a", "http": 99}]}`,
+			wantErr: "invalid HTTP status",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readCatalog(strings.NewReader(tt.catalog))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("readCatalog() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "catalog.json")
+	catalog, err := os.ReadFile(filepath.Join("testdata", "catalog.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(in, catalog, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := run([]string{"-in", in, "-doc", filepath.Join(dir, "ERRORS.md")}); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	for _, name := range []string{"catalog_gen.go", "ERRORS.md"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("run() did not write %s: %v", name, err)
+		}
+	}
+	if err := run(nil); err == nil {
+		t.Errorf("run() without -in error = nil, want an error")
+	}
+}
@@ -0,0 +1,74 @@
+// Command gerrgen generates the kinds of errors of a package from a JSON catalog, the generated code holds an integer
+// type with a constant per kind, its Error, String and Code methods, the registration of the codes with gerr.Register,
+// and, when the catalog maps kinds to them, the HTTP status codes and the exit codes of the kinds. Markdown docs of the
+// catalog can be generated as well. It is intended to be used with go generate:
+//
+//	//go:generate go run github.com/insan1k/gerr/cmd/gerrgen -in errors.json -out errors_gen.go -doc ERRORS.md
+//
+// The catalog is a JSON object:
+//
+//	{
+//	  "package": "store",
+//	  "type": "StoreError",
+//	  "kinds": [
+//	    {"name": "ErrNotFound", "code": "store.not_found", "message": "not found", "http": 404, "exit": 2}
+//	  ]
+//	}
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "gerrgen:", err)
+		os.Exit(1)
+	}
+}
+
+// run reads the catalog and writes the generated files as configured by the given arguments
+func run(args []string) error {
+	fs := flag.NewFlagSet("gerrgen", flag.ContinueOnError)
+	in := fs.String("in", "", "path of the JSON catalog")
+	out := fs.String("out", "", "path of the generated Go file, the catalog path with a _gen.go suffix by default")
+	doc := fs.String("doc", "", "path of the generated Markdown docs, not generated by default")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *in == "" {
+		return fmt.Errorf("missing -in")
+	}
+	if *out == "" {
+		*out = strings.TrimSuffix(*in, filepath.Ext(*in)) + "_gen.go"
+	}
+	f, err := os.Open(*in)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	c, err := readCatalog(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *in, err)
+	}
+	source := filepath.Base(*in)
+	code, err := generateCode(c, source)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		return err
+	}
+	if *doc == "" {
+		return nil
+	}
+	md, err := generateDoc(c, source)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(*doc, md, 0o644)
+}
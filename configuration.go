@@ -1,8 +1,10 @@
 package gerr
 
-var _separator = func() string {
-	return defaultSeparator
-}
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
 
 const (
 	defaultSeparator       = " "
@@ -10,30 +12,228 @@ const (
 	colonAndSpaceSeparator = ": "
 )
 
+// Config holds the defaults used to create errors, errors created through a Config do not depend on the global
+// configuration, which allows library code to create Grr values without touching global state. Every setting is taken
+// as is, the zero Config has an empty separator which concatenates the entries of the chain, a Config that only
+// changes some of the defaults should start from NewConfig or DefaultConfig.
+type Config struct {
+	// Separator is the separator for the error chain, an empty separator is kept as is, see NewConfig
+	Separator string
+	// StringMatching compares errors by their strings when calling Is, instead of comparing them by identity
+	StringMatching bool
+	// StackTrace captures the stack trace of the caller when errors are created, and when errors are added to them
+	StackTrace bool
+	// FieldsInError renders the fields at the end of the string returned by Error
+	FieldsInError bool
+	// Redaction redacts the messages of the errors, see RedactRule
+	Redaction RedactRule
+}
+
+// NewConfig returns a Config holding the defaults of the package, a space separator, identity matching, no stack traces,
+// no fields in the string returned by Error and no redaction, regardless of the global configuration
+func NewConfig() Config {
+	return Config{Separator: defaultSeparator}
+}
+
+// New returns a new Grr using the defaults of the Config, see the package function New
+func (c Config) New(kind error, opts ...Option) Grr {
+	return fire(hookNew, c.newGrr(kind, opts))
+}
+
+// Errorf returns a new Grr from the given format and arguments using the defaults of the Config, see the package
+// function Errorf
+func (c Config) Errorf(format string, a ...interface{}) Grr {
+	return c.New(fmt.Errorf(format, a...))
+}
+
+// AsGrr converts the given error to the package type Grr using the defaults of the Config, see the package function
+// AsGrr
+func (c Config) AsGrr(err error) Grr {
+	if err == nil {
+		return nil
+	}
+	if g, ok := err.(Grr); ok {
+		return g
+	}
+	return fire(hookAsGrr, c.asGrr(err))
+}
+
+// asGrr converts the given error to the package type Grr without firing the hooks, errors that only join other errors,
+// as errors.Join does, are converted to joined errors whose branches are converted individually
+func (c Config) asGrr(err error) Grr {
+	if branches, ok := c.branches(err); ok {
+		return newJoined(branches)
+	}
+	return newWrapped(c, err).grr()
+}
+
+// newGrr returns a new Grr of the given kind without firing the new hooks, when the kind is a joined error, or only
+// joins other errors as errors.Join does, a joined error is returned whose every branch is created with the options
+func (c Config) newGrr(kind error, opts []Option) Grr {
+	branches, ok := c.branches(kind)
+	if !ok {
+		return newWrapped(c, kind, opts...).grr()
+	}
+	errs := make([]Grr, len(branches))
+	for i, b := range branches {
+		errs[i] = newWrapped(c, b, opts...).grr()
+	}
+	return newJoined(errs)
+}
+
+// branches returns the branches of the given error when it is a joined error, or when it only joins other errors as
+// errors.Join does, in which case they are converted with the defaults of the Config
+func (c Config) branches(err error) ([]Grr, bool) {
+	if j, ok := err.(joined); ok {
+		return j.errs, true
+	}
+	if errs, ok := joinedErrors(err); ok {
+		if branches := joinBranches(errs, c.kind()); len(branches) > 0 {
+			return branches, true
+		}
+	}
+	return nil, false
+}
+
+// kind returns a kind with no error configured with the defaults of the Config
+func (c Config) kind() kind {
+	k := kind{
+		separator: c.Separator,
+	}
+	if c.FieldsInError || c.Redaction != nil {
+		k = k.withExtras(func(e *kindExtras) {
+			e.renderFields = c.FieldsInError
+			e.redact = c.Redaction
+		})
+	}
+	if c.StringMatching {
+		k.match = matchString
+	}
+	return k
+}
+
+// _defaults holds the global configuration, it is replaced as a whole on every change so that it can be read without
+// locking, writes are serialized by _defaultsMu
+var (
+	_defaults   atomic.Pointer[Config]
+	_defaultsMu sync.Mutex
+)
+
+func init() {
+	c := NewConfig()
+	_defaults.Store(&c)
+}
+
+// DefaultConfig returns a copy of the global configuration used by the package functions
+func DefaultConfig() Config {
+	return *_defaults.Load()
+}
+
+// SetDefaultConfig replaces the global configuration used by the package functions, the settings are taken as is, see
+// NewConfig
+func SetDefaultConfig(c Config) {
+	updateDefaults(func(d *Config) {
+		*d = c
+	})
+}
+
+// updateDefaults applies the given change to a copy of the global configuration and stores it
+func updateDefaults(change func(c *Config)) {
+	_defaultsMu.Lock()
+	defer _defaultsMu.Unlock()
+	c := *_defaults.Load()
+	change(&c)
+	_defaults.Store(&c)
+}
+
+// _separator returns the global separator
+func _separator() string {
+	return _defaults.Load().Separator
+}
+
 // SetCustomSeparator sets the separator for the error chain
 func SetCustomSeparator(s string) {
-	_separator = func() string {
-		return s
-	}
+	updateDefaults(func(c *Config) {
+		c.Separator = s
+	})
 }
 
 // SetColonSeparator sets the separator for the error chain to a colon
 func SetColonSeparator() {
-	_separator = func() string {
-		return colonSeparator
-	}
+	SetCustomSeparator(colonSeparator)
 }
 
 // SetColonAndSpaceSeparator sets the separator for the error chain to a colon and space
 func SetColonAndSpaceSeparator() {
-	_separator = func() string {
-		return colonAndSpaceSeparator
-	}
+	SetCustomSeparator(colonAndSpaceSeparator)
 }
 
 // SetSpaceSeparator sets the separator for the error chain to a space
 func SetSpaceSeparator() {
-	_separator = func() string {
-		return defaultSeparator
+	SetCustomSeparator(defaultSeparator)
+}
+
+// matchMode is the strategy used by the Is implementations of the package types to compare errors
+type matchMode int
+
+const (
+	// matchIdentity compares errors by identity, as errors.Is does
+	matchIdentity matchMode = iota
+	// matchString compares errors by the string they return from Error
+	matchString
+)
+
+// _matching returns the global match mode
+func _matching() matchMode {
+	if _defaults.Load().StringMatching {
+		return matchString
 	}
+	return matchIdentity
+}
+
+// SetStringMatching sets the errors created from now on to be compared by their strings when calling Is
+func SetStringMatching() {
+	updateDefaults(func(c *Config) {
+		c.StringMatching = true
+	})
+}
+
+// SetIdentityMatching sets the errors created from now on to be compared by identity when calling Is, this is the
+// default
+func SetIdentityMatching() {
+	updateDefaults(func(c *Config) {
+		c.StringMatching = false
+	})
+}
+
+// EnableStackTrace sets the errors created from now on, and the errors added to them, to capture the stack trace of
+// their caller
+func EnableStackTrace() {
+	updateDefaults(func(c *Config) {
+		c.StackTrace = true
+	})
+}
+
+// DisableStackTrace sets the errors created from now on to not capture stack traces, unless requested by WithStackTrace,
+// this is the default
+func DisableStackTrace() {
+	updateDefaults(func(c *Config) {
+		c.StackTrace = false
+	})
+}
+
+// EnableFieldsInError sets the errors created from now on to render their fields at the end of the string returned by
+// Error
+func EnableFieldsInError() {
+	updateDefaults(func(c *Config) {
+		c.FieldsInError = true
+	})
+}
+
+// DisableFieldsInError sets the errors created from now on to not render their fields in the string returned by Error,
+// unless requested by WithFieldsInError, this is the default
+func DisableFieldsInError() {
+	updateDefaults(func(c *Config) {
+		c.FieldsInError = false
+	})
 }
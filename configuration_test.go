@@ -1,7 +1,10 @@
 package gerr
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -70,9 +73,7 @@ func customSeparatorTestfunc(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_separator = func() string {
-				return ""
-			}
+			SetCustomSeparator("")
 			SetCustomSeparator(tt.args.s)
 			if got := _separator(); got != tt.want {
 				t.Errorf("_separator() = %v, want %v", got, tt.want)
@@ -104,9 +105,7 @@ func colonSeparatorTestFunc(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_separator = func() string {
-				return ""
-			}
+			SetCustomSeparator("")
 			SetColonSeparator()
 			if got := _separator(); got != ":" {
 				t.Errorf("_separator() = %v, want %v", got, ":")
@@ -138,9 +137,7 @@ func colonAndSpaceSeparatorTestFunc(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_separator = func() string {
-				return ""
-			}
+			SetCustomSeparator("")
 			SetColonAndSpaceSeparator()
 			if got := _separator(); got != ": " {
 				t.Errorf("_separator() = %v, want %v", got, ": ")
@@ -172,9 +169,7 @@ func spaceSeparatorTestFunc(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_separator = func() string {
-				return ""
-			}
+			SetCustomSeparator("")
 			SetSpaceSeparator()
 			if got := _separator(); got != " " {
 				t.Errorf("_separator() = %v, want %v", got, " ")
@@ -232,3 +227,108 @@ func defaultSeparatorTestFunc(t *testing.T) {
 		})
 	}
 }
+
+func Test_matching(t *testing.T) {
+	defer SetIdentityMatching()
+	if got := _matching(); got != matchIdentity {
+		t.Errorf("_matching() = %v, want %v", got, matchIdentity)
+	}
+	SetStringMatching()
+	if got := _matching(); got != matchString {
+		t.Errorf("_matching() = %v, want %v", got, matchString)
+	}
+	if !New(errors.New("one")).Is(errors.New("one")) {
+		t.Errorf("Is() = false, want true when matching by string")
+	}
+	SetIdentityMatching()
+	if New(errors.New("one")).Is(errors.New("one")) {
+		t.Errorf("Is() = true, want false when matching by identity")
+	}
+	if !New(errors.New("one"), WithStringMatching()).Is(errors.New("one")) {
+		t.Errorf("Is() = false, want true when matching by string")
+	}
+}
+
+func TestConfig(t *testing.T) {
+	c := Config{Separator: ": ", StringMatching: true, FieldsInError: true}
+	got := c.New(errors.New("one"), WithField("user", "someUser")).Add(errors.New("two"))
+	if want := "one: two user=someUser"; got.Error() != want {
+		t.Errorf("Error() = %v, want %v", got.Error(), want)
+	}
+	if !got.Is(errors.New("two")) {
+		t.Errorf("Is() = false, want true when matching by string")
+	}
+	if got := c.Errorf("three: %w", errors.New("four")); got.Error() != "three: four" {
+		t.Errorf("Errorf() = %v, want %v", got, "three: four")
+	}
+	if got := c.AsGrr(errors.New("five")).Add(errors.New("six")); got.Error() != "five: six" {
+		t.Errorf("AsGrr() = %v, want %v", got, "five: six")
+	}
+	if got := _separator(); got == c.Separator {
+		t.Errorf("_separator() = %q, the global configuration must not be changed by a Config", got)
+	}
+}
+
+func TestNewConfig(t *testing.T) {
+	c := NewConfig()
+	c.FieldsInError = true
+	got := c.New(errors.New("one"), WithField("user", "someUser")).Add(errors.New("two"))
+	if want := "one two user=someUser"; got.Error() != want {
+		t.Errorf("Error() = %v, want %v", got.Error(), want)
+	}
+	if got := (Config{}).New(errors.New("one")).Add(errors.New("two")); got.Error() != "onetwo" {
+		t.Errorf("Error() = %v, want %v", got, "onetwo")
+	}
+}
+
+func TestWithSeparator(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Grr
+		want string
+	}{
+		{
+			name: "Kind",
+			got:  New(errors.New("one"), WithSeparator(" -- ")).Add(errors.New("two")),
+			want: "one -- two",
+		},
+		{
+			name: "WithErr",
+			got:  New(errors.New("one"), WithSeparator(" -- "), WithErr(errors.New("two")), WithErrorf("three")),
+			want: "one -- three -- two",
+		},
+		{
+			name: "WrappedError",
+			got: New(
+				fmt.Errorf("one%s%w", _separator(), fmt.Errorf("two%s%w", _separator(), errors.New("three"))),
+				WithSeparator(" -- "),
+			),
+			want: "one -- two -- three",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.Error(); got != tt.want {
+				t.Errorf("Error() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultConfigConcurrency(t *testing.T) {
+	defer SetDefaultConfig(DefaultConfig())
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetColonSeparator()
+			SetSpaceSeparator()
+		}()
+		go func() {
+			defer wg.Done()
+			_ = New(errors.New("one")).Add(errors.New("two")).Error()
+		}()
+	}
+	wg.Wait()
+}
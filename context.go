@@ -0,0 +1,167 @@
+package gerr
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// contextKey is the type of the keys of the values this package stores in a context.Context
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	traceparentKey
+)
+
+// traceContext holds the identifiers parsed from a W3C traceparent header
+type traceContext struct {
+	traceID, spanID string
+}
+
+// contextField is a value of a context.Context attached as a field by WithContext, see RegisterContextField
+type contextField struct {
+	key    any
+	name   string
+	public bool
+}
+
+// _contextFields holds the context keys registered with RegisterContextField and RegisterPublicContextField, in the
+// order they were registered
+var _contextFields = struct {
+	sync.RWMutex
+	fields []contextField
+}{}
+
+// ContextWithRequestID returns a copy of the context carrying the given request ID, which WithContext attaches as the
+// public request_id field, so that it is kept when the error is sanitized and can be reported back to the client
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// ContextWithTraceparent returns a copy of the context carrying the trace and span IDs of the given W3C traceparent
+// header, which WithContext attaches as the trace_id and span_id fields, the context is returned as is when the header
+// is not valid
+func ContextWithTraceparent(ctx context.Context, traceparent string) context.Context {
+	tc, ok := parseTraceparent(traceparent)
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, traceparentKey, tc)
+}
+
+// RegisterContextField registers a context key whose value WithContext attaches as the field with the given name, the
+// field is removed when the error is sanitized, registering a key again replaces its name
+func RegisterContextField(name string, key any) {
+	registerContextField(contextField{key: key, name: name})
+}
+
+// RegisterPublicContextField registers a context key whose value WithContext attaches as the field with the given
+// name, the field is kept when the error is sanitized, see RegisterContextField
+func RegisterPublicContextField(name string, key any) {
+	registerContextField(contextField{key: key, name: name, public: true})
+}
+
+// registerContextField adds the given field to the registered ones, replacing the one with the same key
+func registerContextField(f contextField) {
+	_contextFields.Lock()
+	defer _contextFields.Unlock()
+	for i, existing := range _contextFields.fields {
+		if existing.key == f.key {
+			_contextFields.fields[i] = f
+			return
+		}
+	}
+	_contextFields.fields = append(_contextFields.fields, f)
+}
+
+// WithContext attaches the correlation data carried by the context to the package type Grr, the request ID and the
+// trace and span IDs, see ContextWithRequestID and ContextWithTraceparent, and the values of the registered context
+// keys, see RegisterContextField. When the context is done its error, context.Canceled or context.DeadlineExceeded, is
+// added to the chain followed by its cause, see context.Cause, unless the Grr already matches them, so that the Grr
+// reports why the context was cancelled.
+func WithContext(ctx context.Context) Option {
+	return func(w wrapped) wrapped {
+		if fields := contextFields(ctx); len(fields) > 0 {
+			w = WithFields(fields...)(w)
+		}
+		err := ctx.Err()
+		if err == nil {
+			return w
+		}
+		if cause := context.Cause(ctx); cause != nil && cause != err && !w.Is(cause) {
+			w = withErr(w, cause, nil)
+			w.added = true
+		}
+		if !w.Is(err) {
+			w = withErr(w, err, nil)
+			w.added = true
+		}
+		return w
+	}
+}
+
+// NewCtx returns a new Grr of the given kind carrying the correlation data and the cancellation cause of the context,
+// see WithContext, the given options are applied afterwards
+func NewCtx(ctx context.Context, kind error, opts ...Option) Grr {
+	return fire(hookNew, DefaultConfig().newGrr(kind, append([]Option{WithContext(ctx)}, opts...)))
+}
+
+// ContextError returns the error of the context as a Grr whose chain holds the cause of the cancellation, see
+// context.Cause, or nil when the context is not done
+func ContextError(ctx context.Context) Grr {
+	err := ctx.Err()
+	if err == nil {
+		return nil
+	}
+	if cause := context.Cause(ctx); cause != nil && cause != err {
+		return New(err, chainErr(cause))
+	}
+	return New(err)
+}
+
+// contextFields returns the fields of the correlation data carried by the context
+func contextFields(ctx context.Context) []Field {
+	var fields []Field
+	if id, ok := ctx.Value(requestIDKey).(string); ok && id != "" {
+		fields = append(fields, Field{Key: "request_id", Value: id, Public: true})
+	}
+	if tc, ok := ctx.Value(traceparentKey).(traceContext); ok {
+		fields = append(fields, Field{Key: "trace_id", Value: tc.traceID}, Field{Key: "span_id", Value: tc.spanID})
+	}
+	_contextFields.RLock()
+	defer _contextFields.RUnlock()
+	for _, f := range _contextFields.fields {
+		if v := ctx.Value(f.key); v != nil {
+			fields = append(fields, Field{Key: f.name, Value: v, Public: f.public})
+		}
+	}
+	return fields
+}
+
+// parseTraceparent parses a W3C traceparent header, version-traceid-parentid-flags, the second value reports whether
+// the header is valid, the IDs must not be all zeros and the version ff is invalid
+func parseTraceparent(s string) (traceContext, bool) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) < 4 || !isLowerHex(parts[0], 2) || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) ||
+		!isLowerHex(parts[1], 32) || !isLowerHex(parts[2], 16) || !isLowerHex(parts[3], 2) {
+		return traceContext{}, false
+	}
+	if strings.Trim(parts[1], "0") == "" || strings.Trim(parts[2], "0") == "" {
+		return traceContext{}, false
+	}
+	return traceContext{traceID: parts[1], spanID: parts[2]}, true
+}
+
+// isLowerHex reports whether the string is made of the given amount of lower case hexadecimal digits
+func isLowerHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,177 @@
+package gerr
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type tenantKey struct{}
+
+type userKey struct{}
+
+var (
+	errCtxRead       = errors.New("failed to read")
+	errCtxDisconnect = errors.New("client disconnected")
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestNewCtx(t *testing.T) {
+	RegisterPublicContextField("tenant", tenantKey{})
+	RegisterContextField("user", userKey{})
+
+	base := ContextWithTraceparent(ContextWithRequestID(context.Background(), "req-1"), testTraceparent)
+	base = context.WithValue(context.WithValue(base, tenantKey{}, "acme"), userKey{}, "bob")
+	correlation := []Field{
+		{Key: "request_id", Value: "req-1", Public: true},
+		{Key: "trace_id", Value: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{Key: "span_id", Value: "00f067aa0ba902b7"},
+		{Key: "tenant", Value: "acme", Public: true},
+		{Key: "user", Value: "bob"},
+	}
+	cancelled, cancel := context.WithCancelCause(base)
+	cancel(errCtxDisconnect)
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Unix(0, 0))
+	defer cancelExpired()
+	plain, cancelPlain := context.WithCancel(context.Background())
+	cancelPlain()
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		kind       error
+		opts       []Option
+		wantError  string
+		wantFields []Field
+		wantIs     []error
+	}{
+		{
+			name:      "Empty",
+			ctx:       context.Background(),
+			kind:      errCtxRead,
+			wantError: "failed to read",
+		},
+		{
+			name:       "Correlation",
+			ctx:        base,
+			kind:       errCtxRead,
+			wantError:  "failed to read",
+			wantFields: correlation,
+		},
+		{
+			name:       "Cause",
+			ctx:        cancelled,
+			kind:       errCtxRead,
+			wantError:  "failed to read context canceled client disconnected",
+			wantFields: correlation,
+			wantIs:     []error{errCtxRead, context.Canceled, errCtxDisconnect},
+		},
+		{
+			name:       "CauseAfterOptions",
+			ctx:        cancelled,
+			kind:       errCtxRead,
+			opts:       []Option{WithErr(errors.New("query interrupted"))},
+			wantError:  "failed to read query interrupted context canceled client disconnected",
+			wantFields: correlation,
+			wantIs:     []error{context.Canceled, errCtxDisconnect},
+		},
+		{
+			name:       "ContextErrorKind",
+			ctx:        cancelled,
+			kind:       context.Canceled,
+			wantError:  "context canceled client disconnected",
+			wantFields: correlation,
+			wantIs:     []error{context.Canceled, errCtxDisconnect},
+		},
+		{
+			name:      "Deadline",
+			ctx:       expired,
+			kind:      errCtxRead,
+			wantError: "failed to read context deadline exceeded",
+			wantIs:    []error{context.DeadlineExceeded},
+		},
+		{
+			name:      "NoCause",
+			ctx:       plain,
+			kind:      errCtxRead,
+			wantError: "failed to read context canceled",
+			wantIs:    []error{context.Canceled},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewCtx(tt.ctx, tt.kind, tt.opts...)
+			if got.Error() != tt.wantError {
+				t.Errorf("NewCtx() = %q, want %q", got.Error(), tt.wantError)
+			}
+			if !reflect.DeepEqual(got.Fields(), tt.wantFields) {
+				t.Errorf("NewCtx().Fields() = %v, want %v", got.Fields(), tt.wantFields)
+			}
+			for _, target := range tt.wantIs {
+				if !got.Is(target) {
+					t.Errorf("NewCtx().Is(%v) = false, want true", target)
+				}
+			}
+		})
+	}
+}
+
+func TestNewCtxSanitize(t *testing.T) {
+	ctx := ContextWithTraceparent(ContextWithRequestID(context.Background(), "req-1"), testTraceparent)
+	got := NewCtx(ctx, errCtxRead).Sanitize().Fields()
+	if want := []Field{{Key: "request_id", Value: "req-1", Public: true}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NewCtx().Sanitize().Fields() = %v, want %v", got, want)
+	}
+}
+
+func TestContextError(t *testing.T) {
+	if got := ContextError(context.Background()); got != nil {
+		t.Errorf("ContextError() = %v, want nil", got)
+	}
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errCtxDisconnect)
+	got := ContextError(ctx)
+	if got.Error() != "context canceled client disconnected" || !got.Is(context.Canceled) || !got.Is(errCtxDisconnect) {
+		t.Errorf("ContextError() = %v, want context canceled caused by %v", got, errCtxDisconnect)
+	}
+}
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   traceContext
+		wantOk bool
+	}{
+		{
+			name:   "Valid",
+			header: testTraceparent,
+			want:   traceContext{traceID: "4bf92f3577b34da6a3ce929d0e0e4736", spanID: "00f067aa0ba902b7"},
+			wantOk: true,
+		},
+		{
+			name:   "FutureVersion",
+			header: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			want:   traceContext{traceID: "4bf92f3577b34da6a3ce929d0e0e4736", spanID: "00f067aa0ba902b7"},
+			wantOk: true,
+		},
+		{name: "Empty", header: ""},
+		{name: "InvalidVersion", header: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		{name: "ExtraFields", header: testTraceparent + "-extra"},
+		{name: "ZeroTraceID", header: "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+		{name: "ZeroSpanID", header: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01"},
+		{name: "UpperCase", header: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"},
+		{name: "Short", header: "00-4bf92f3577b34da6-00f067aa0ba902b7-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseTraceparent(tt.header)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("parseTraceparent() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
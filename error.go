@@ -1,32 +1,50 @@
 package gerr
 
-import "fmt"
+import (
+	"fmt"
+	"runtime"
+)
 
 // New returns the package type Wrapped as a standard error if the given arguments contain a wrapped error, or supply a
-// error to wrap using WithErr, otherwise it returns Kind which implements the package Grr interface.
+// error to wrap using WithErr, otherwise it returns Kind which implements the package Grr interface. A kind that joins
+// other errors, as errors.Join and Join do, returns a joined Grr whose every branch is created with the options.
 func New(kind error, opts ...Option) Grr {
-	e := newWrapped(kind, opts...)
-	if e.err != nil {
-		return e
-	}
-	return e.kind
+	return fire(hookNew, DefaultConfig().newGrr(kind, opts))
 }
 
 // WithErr embeds an error on the package type Wrapped, when calling WithErr multiple times note that the first call
 // will be considered as the original error, subsequent calls will wrap that error. Additionally, if the given kind
 // error contains a wrapped error, the given error will be added to the chain of the wrapped error and will not
-// overwrite the original error.
-func WithErr(err error) Option {
+// overwrite the original error. The embedded error is internal unless another visibility is given, see SanitizeTo.
+func WithErr(err error, v ...Visibility) Option {
 	return func(w wrapped) wrapped {
-		if w.err != nil {
-			w = w.Add(err).(wrapped)
-		} else {
-			w.err = err
-		}
+		w = withErr(w, err, v)
+		w.added = w.added || err != nil
 		return w
 	}
 }
 
+// withErr embeds the error on the package type Wrapped without firing the hooks, see WithErr
+func withErr(w wrapped, err error, v []Visibility) wrapped {
+	switch {
+	case w.err != nil:
+		return w.add(err, v)
+	case visibilityOf(v) != Internal:
+		w.err = layer{orig: err, separator: w.kind.separator, visibility: visibilityOf(v)}
+	default:
+		w.err = err
+	}
+	return w
+}
+
+// chainErr is the Option of WithErr that does not fire the add hooks, it is used by the functions of this package that
+// build a chain as part of firing their own hook
+func chainErr(err error) Option {
+	return func(w wrapped) wrapped {
+		return withErr(w, err, nil)
+	}
+}
+
 // Errorf creates a new package type Wrapped from the given format and arguments, if the given arguments contain a
 // wrapped error, or supply an error to wrap using WithErr, otherwise it returns Kind which implements the package Grr
 // interface.
@@ -42,21 +60,66 @@ func WithErrorf(format string, a ...interface{}) Option {
 	return WithErr(fmt.Errorf(format, a...))
 }
 
+// WithSeparator configures the package type Grr to use the given separator for its error chain instead of the one of
+// the global configuration, note that errors that wrap other errors given to New are split using the separator that
+// was configured before this option was applied
+func WithSeparator(sep string) Option {
+	return func(w wrapped) wrapped {
+		w.kind.separator = sep
+		if l, ok := w.kind.err.(layer); ok {
+			l.separator = sep
+			w.kind.err = l
+		}
+		w.err = relinkLayers(w.err, sep)
+		return w
+	}
+}
+
+// WithStringMatching configures the package type Grr to compare errors by their strings when calling Is, instead of
+// comparing them by identity
+func WithStringMatching() Option {
+	return func(w wrapped) wrapped {
+		w.kind.match = matchString
+		return w
+	}
+}
+
+// WithStackTrace configures the package type Grr to capture the stack trace of its caller, errors added to it later
+// on capture the stack trace of their caller as well
+func WithStackTrace() Option {
+	return func(w wrapped) wrapped {
+		if w.kind.stack == nil {
+			w.kind.stack = callers()
+		}
+		return w
+	}
+}
+
 // Option is the functional type for configuring a package type Grr
 type Option func(w wrapped) wrapped
 
 // Grr represents the interface that all error helper types implement
 type Grr interface {
-	// Add adds the given error to the error chain
-	Add(err error) Grr
+	// Add adds the given error to the error chain, the entry is internal unless another visibility is given
+	Add(err error, v ...Visibility) Grr
+	// Append joins the given errors with the Grr, returning a Grr that holds all of them as branches
+	Append(errs ...error) Grr
 	// Error implements the error interface
 	Error() string
 	// Chain returns the error chain as a slice of errors
 	Chain() []error
 	// Is returns true if the error is of the given type, or exists in its chain
 	Is(err error) bool
-	// Sanitize removes all additional context from the Grr
+	// Sanitize removes all additional context from the Grr that is not public
 	Sanitize() Grr
+	// SanitizeTo removes the entries of the chain and the fields that are not visible to the given audience
+	SanitizeTo(audience Visibility) Grr
+	// StackTrace returns the stack trace captured when the Grr was created, or nil when none was captured
+	StackTrace() []runtime.Frame
+	// Fields returns the key value pairs attached to the Grr
+	Fields() []Field
+	// Code returns the stable code the kind of the Grr was registered with, or an empty string if it was not registered
+	Code() string
 }
 
 // AsGrr converts the given error to the package type Grr, if the given error is nil, or is not of the package type Grr
@@ -67,5 +130,5 @@ func AsGrr(err error) Grr {
 	if g, ok := err.(Grr); ok {
 		return g
 	}
-	return New(err)
+	return fire(hookAsGrr, DefaultConfig().asGrr(err))
 }
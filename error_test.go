@@ -88,7 +88,7 @@ func TestNew(t *testing.T) {
 			got := New(tt.args.kind, tt.args.opts...)
 			originalGot := got
 			originalWant := tt.want
-			if !reflect.DeepEqual(got, tt.want) {
+			if !equalGrr(got, tt.want) {
 				t.Errorf("New() = %v, want %v", got, tt.want)
 				t.FailNow()
 			}
@@ -99,14 +99,14 @@ func TestNew(t *testing.T) {
 				t.Errorf("New().Error() = %v, want %v", got.Error(), tt.want.Error())
 			}
 			addedErr := errors.New("addedErrNow")
-			if !reflect.DeepEqual(got.Add(addedErr), tt.want.Add(addedErr)) {
+			if !equalGrr(got.Add(addedErr), tt.want.Add(addedErr)) {
 				t.Errorf("New().Add() = %v, want %v", got.Add(addedErr), tt.want.Add(addedErr))
 			}
 			notAddedErr := errors.New("notAdded")
 			if !reflect.DeepEqual(got.Is(notAddedErr), tt.want.Is(notAddedErr)) {
 				t.Errorf("New().Is() = %v, want %v", got.Is(notAddedErr), tt.want.Is(notAddedErr))
 			}
-			if !reflect.DeepEqual(got.Sanitize(), tt.want.Sanitize()) {
+			if !equalGrr(got.Sanitize(), tt.want.Sanitize()) {
 				t.Errorf("New().Sanitize() = %v, want %v", got.Sanitize(), tt.want.Sanitize())
 			}
 			if !reflect.DeepEqual(originalGot, got) {
@@ -119,6 +119,29 @@ func TestNew(t *testing.T) {
 	}
 }
 
+// equalGrr compares two Grr by their type, configuration, message and chain, the original error values held by their
+// layers are not compared as they cannot be recreated by the tests
+func equalGrr(a, b Grr) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || a.Error() != b.Error() {
+		return false
+	}
+	ak, aOk := a.Sanitize().(kind)
+	bk, bOk := b.Sanitize().(kind)
+	if aOk != bOk || ak.separator != bk.separator || ak.match != bk.match {
+		return false
+	}
+	aChain, bChain := a.Chain(), b.Chain()
+	if len(aChain) != len(bChain) {
+		return false
+	}
+	for i := range aChain {
+		if aChain[i].Error() != bChain[i].Error() {
+			return false
+		}
+	}
+	return true
+}
+
 func genWrappedWithSeparatorErrNoTop(count int) error {
 	return genWrappedErrNoTop(count, _separator(), "")
 }
@@ -249,3 +249,39 @@ func readUserTestIs(err error, t *testing.T) {
 		t.Fatalf("expected %v, got %v", fakeDbError, err)
 	}
 }
+
+func TestExampleFields(t *testing.T) {
+	err := readUserWithFields("someUser", "someGroup")
+	if !gerr.AsGrr(err).Is(ErrorExampleFailedToRead) {
+		t.Fatalf("expected %v, got %v", ErrorExampleFailedToRead, err)
+	}
+	if !gerr.AsGrr(err).Is(fakeDbError) {
+		t.Fatalf("expected %v, got %v", fakeDbError, err)
+	}
+	log.Println("----------------------------------------")
+	log.Println("before sanitize")
+	log.Println(err)
+	sanitized := gerr.AsGrr(err).Sanitize()
+	if sanitized.Is(fakeDbError) {
+		t.Fatalf("expected %v to be sanitized, got %v", fakeDbError, sanitized)
+	}
+	if len(sanitized.Fields()) != 1 {
+		t.Fatalf("expected only the public fields, got %v", sanitized.Fields())
+	}
+	log.Println("after sanitize")
+	log.Println(sanitized)
+	log.Println("----------------------------------------")
+}
+
+var readUserWithFields = func(user, group string) error {
+	if err := fakeDb(user, group); err != nil {
+		return gerr.New(ErrorExampleFailedToRead,
+			gerr.WithErr(err),
+			gerr.WithFieldsInError(),
+			gerr.WithField("user", user),
+			gerr.WithField("group", group),
+			gerr.WithPublicField("reason", "invalid user id"),
+		)
+	}
+	return nil
+}
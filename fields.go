@@ -0,0 +1,109 @@
+package gerr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Field is a key value pair of context attached to a Grr, public fields are kept when the Grr is sanitized
+type Field struct {
+	Key    string `json:"key"`
+	Value  any    `json:"value"`
+	Public bool   `json:"public,omitempty"`
+}
+
+// String returns the field formatted as key=value
+func (f Field) String() string {
+	return fmt.Sprintf("%s=%v", f.Key, f.Value)
+}
+
+// WithField attaches the given key and value to the package type Grr, the field is removed when the Grr is sanitized
+func WithField(key string, value any) Option {
+	return WithFields(Field{Key: key, Value: value})
+}
+
+// WithPublicField attaches the given key and value to the package type Grr, the field is kept when the Grr is
+// sanitized
+func WithPublicField(key string, value any) Option {
+	return WithFields(Field{Key: key, Value: value, Public: true})
+}
+
+// WithFields attaches the given fields to the package type Grr, a field replaces the value of a previously attached
+// field with the same key, the fields are kept when errors are added to the Grr
+func WithFields(fields ...Field) Option {
+	return func(w wrapped) wrapped {
+		w.kind = w.kind.withExtras(func(e *kindExtras) {
+			e.fields = mergeFields(e.fields, fields)
+		})
+		return w
+	}
+}
+
+// WithFieldsInError configures the package type Grr to render its fields at the end of the string returned by Error
+func WithFieldsInError() Option {
+	return func(w wrapped) wrapped {
+		w.kind = w.kind.withExtras(func(e *kindExtras) {
+			e.renderFields = true
+		})
+		return w
+	}
+}
+
+// mergeFields returns a new slice with the given fields merged into the existing ones, the existing slice is never
+// modified so that the Grr sharing it stay immutable
+func mergeFields(existing, fields []Field) []Field {
+	if len(fields) == 0 {
+		return existing
+	}
+	merged := make([]Field, len(existing), len(existing)+len(fields))
+	copy(merged, existing)
+	for _, f := range fields {
+		if i := indexField(merged, f.Key); i >= 0 {
+			merged[i] = f
+			continue
+		}
+		merged = append(merged, f)
+	}
+	return merged
+}
+
+// indexField returns the index of the field with the given key, or -1 if there is none
+func indexField(fields []Field, key string) int {
+	for i, f := range fields {
+		if f.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// publicFields returns the public fields, or nil if there are none
+func publicFields(fields []Field) []Field {
+	var public []Field
+	for _, f := range fields {
+		if f.Public {
+			public = append(public, f)
+		}
+	}
+	return public
+}
+
+// copyFields returns a copy of the given fields, or nil if there are none
+func copyFields(fields []Field) []Field {
+	if len(fields) == 0 {
+		return nil
+	}
+	return append([]Field(nil), fields...)
+}
+
+// fieldsString renders the fields to be appended to the string returned by Error
+func fieldsString(fields []Field) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	s := make([]string, len(fields))
+	for i, f := range fields {
+		s[i] = f.String()
+	}
+	return " " + strings.Join(s, " ")
+}
@@ -0,0 +1,153 @@
+package gerr
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWithFields(t *testing.T) {
+	sep := _separator()
+	tests := []struct {
+		name          string
+		got           Grr
+		wantFields    []Field
+		wantSanitized []Field
+		wantError     string
+	}{
+		{
+			name:      "NoFields",
+			got:       New(errors.New("one")),
+			wantError: "one",
+		},
+		{
+			name:          "Field",
+			got:           New(errors.New("one"), WithField("user", "someUser")),
+			wantFields:    []Field{{Key: "user", Value: "someUser"}},
+			wantSanitized: nil,
+			wantError:     "one",
+		},
+		{
+			name:          "PublicField",
+			got:           New(errors.New("one"), WithPublicField("reason", "invalid user id")),
+			wantFields:    []Field{{Key: "reason", Value: "invalid user id", Public: true}},
+			wantSanitized: []Field{{Key: "reason", Value: "invalid user id", Public: true}},
+			wantError:     "one",
+		},
+		{
+			name: "FieldsAcrossAdd",
+			got: New(errors.New("one"), WithField("user", "someUser")).
+				Add(errors.New("two")).
+				Add(errors.New("three")),
+			wantFields: []Field{{Key: "user", Value: "someUser"}},
+			wantError:  "one" + sep + "three" + sep + "two",
+		},
+		{
+			name: "FieldsReplaced",
+			got: New(
+				New(errors.New("one"), WithField("user", "someUser"), WithField("group", "someGroup")),
+				WithPublicField("user", "otherUser"),
+			),
+			wantFields: []Field{
+				{Key: "user", Value: "otherUser", Public: true},
+				{Key: "group", Value: "someGroup"},
+			},
+			wantSanitized: []Field{{Key: "user", Value: "otherUser", Public: true}},
+			wantError:     "one",
+		},
+		{
+			name: "FieldsInError",
+			got: New(errors.New("one"), WithFieldsInError(), WithFields(
+				Field{Key: "user", Value: "someUser"},
+				Field{Key: "group", Value: "someGroup", Public: true},
+			)).Add(errors.New("two")),
+			wantFields: []Field{
+				{Key: "user", Value: "someUser"},
+				{Key: "group", Value: "someGroup", Public: true},
+			},
+			wantSanitized: []Field{{Key: "group", Value: "someGroup", Public: true}},
+			wantError:     "one" + sep + "two user=someUser group=someGroup",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.Fields(); !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("Fields() = %v, want %v", got, tt.wantFields)
+			}
+			if got := tt.got.Sanitize().Fields(); !reflect.DeepEqual(got, tt.wantSanitized) {
+				t.Errorf("Sanitize().Fields() = %v, want %v", got, tt.wantSanitized)
+			}
+			if got := tt.got.Error(); got != tt.wantError {
+				t.Errorf("Error() = %v, want %v", got, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestWithFieldsImmutable(t *testing.T) {
+	base := New(errors.New("one"), WithField("user", "someUser"))
+	first := New(base, WithField("group", "first"))
+	second := New(base, WithField("group", "second"))
+	if got := base.Fields(); len(got) != 1 {
+		t.Errorf("Fields() = %v, want only the original field", got)
+	}
+	if got := first.Fields()[1].Value; got != "first" {
+		t.Errorf("Fields()[1] = %v, want %v", got, "first")
+	}
+	if got := second.Fields()[1].Value; got != "second" {
+		t.Errorf("Fields()[1] = %v, want %v", got, "second")
+	}
+	fields := base.Fields()
+	fields[0].Value = "modified"
+	if got := base.Fields()[0].Value; got != "someUser" {
+		t.Errorf("Fields()[0] = %v, want %v", got, "someUser")
+	}
+}
+
+var errComparable = Config{Separator: defaultSeparator}.New(errors.New("comparable"), WithField("user", "someUser"), WithRedaction(DefaultRedaction()))
+
+func TestComparable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  Grr
+	}{
+		{
+			name: "Kind",
+			err:  New(errors.New("one")),
+		},
+		{
+			name: "Fields",
+			err:  errComparable,
+		},
+		{
+			name: "Traits",
+			err:  New(errors.New("one"), WithTraits(Retryable), WithFieldsInError()),
+		},
+		{
+			name: "Wrapped",
+			err:  errComparable.Add(errors.New("two")),
+		},
+		{
+			name: "Joined",
+			err:  Join(errComparable, errors.New("two")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error = tt.err
+			if err != tt.err {
+				t.Errorf("%v == %v = false, want true", err, tt.err)
+			}
+			if err == errComparable && tt.name != "Fields" {
+				t.Errorf("%v == %v = true, want false", err, errComparable)
+			}
+			seen := map[error]bool{tt.err: true}
+			if !seen[err] {
+				t.Errorf("map[%v] = false, want true", err)
+			}
+		})
+	}
+	if err := Register("fields.comparable", errComparable); err != nil {
+		t.Errorf("Register() error = %v, want nil", err)
+	}
+}
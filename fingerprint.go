@@ -0,0 +1,126 @@
+package gerr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Normalizer is the functional type that rewrites the variable parts of a message, such as identifiers and numbers,
+// so that the messages of the occurrences of the same problem are equal, see Fingerprint
+type Normalizer func(s string) string
+
+var (
+	quotedPattern     = regexp.MustCompile("\"(?:[^\"\\\\]|\\\\.)*\"|'(?:[^'\\\\]|\\\\.)*'|`[^`]*`")
+	uuidPattern       = regexp.MustCompile(`\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b`)
+	pathPattern       = regexp.MustCompile(`(^|[^\w.:/~])((?:\.{1,2}|~)?(?:/[\w.@+-]+)+/?|[a-zA-Z]:\\[^\s:;,]+)`)
+	hexPattern        = regexp.MustCompile(`\b0[xX][0-9a-fA-F]+\b|\b[0-9a-fA-F]{8,}\b`)
+	numberPattern     = regexp.MustCompile(`[-+]?\b\d+(?:\.\d+)?`)
+	identifierPattern = regexp.MustCompile(`\b[a-zA-Z0-9_]*(?:[a-z][A-Z]|[a-zA-Z][0-9]|[0-9][a-zA-Z])[a-zA-Z0-9_]*\b`)
+	hasDigitPattern   = regexp.MustCompile(`[0-9]`)
+)
+
+// NormalizeRegexp returns a normalizer that replaces the matches of the given regular expression with the given
+// replacement, which can refer to the submatches as regexp.Regexp.ReplaceAllString does
+func NormalizeRegexp(re *regexp.Regexp, replacement string) Normalizer {
+	return replaceRegexp(re, replacement)
+}
+
+// NormalizePattern returns a normalizer that replaces the matches of the given pattern with the given replacement, it
+// panics if the pattern does not compile, see NormalizeRegexp
+func NormalizePattern(pattern, replacement string) Normalizer {
+	return NormalizeRegexp(regexp.MustCompile(pattern), replacement)
+}
+
+// NormalizeQuoted returns a normalizer that replaces the values quoted with double quotes, single quotes or backticks
+// with [QUOTED]
+func NormalizeQuoted() Normalizer {
+	return NormalizeRegexp(quotedPattern, "[QUOTED]")
+}
+
+// NormalizeUUIDs returns a normalizer that replaces UUIDs with [UUID]
+func NormalizeUUIDs() Normalizer {
+	return NormalizeRegexp(uuidPattern, "[UUID]")
+}
+
+// NormalizePaths returns a normalizer that replaces absolute and relative file system paths with [PATH], the paths of
+// URLs are kept
+func NormalizePaths() Normalizer {
+	return NormalizeRegexp(pathPattern, "${1}[PATH]")
+}
+
+// NormalizeHex returns a normalizer that replaces hexadecimal values with [HEX], either prefixed with 0x, or of at
+// least eight digits including a decimal one, so that words made of the letters a to f are kept
+func NormalizeHex() Normalizer {
+	return func(s string) string {
+		return hexPattern.ReplaceAllStringFunc(s, func(candidate string) string {
+			if !strings.HasPrefix(strings.ToLower(candidate), "0x") && !hasDigitPattern.MatchString(candidate) {
+				return candidate
+			}
+			return "[HEX]"
+		})
+	}
+}
+
+// NormalizeNumbers returns a normalizer that replaces integer and decimal numbers with [NUMBER], the units that follow
+// them are kept, so that 1.5s becomes [NUMBER]s, while the digits within words, such as user42, are not replaced
+func NormalizeNumbers() Normalizer {
+	return NormalizeRegexp(numberPattern, "[NUMBER]")
+}
+
+// NormalizeIdentifiers returns a normalizer that replaces the words that look like identifiers rather than prose with
+// [ID], which are the camel case words, such as someGroup, and the words mixing letters and digits, such as user42
+func NormalizeIdentifiers() Normalizer {
+	return NormalizeRegexp(identifierPattern, "[ID]")
+}
+
+// DefaultNormalization returns a normalizer composed of all the builtin normalizers, quoted values are replaced first
+// so that whatever they hold is replaced as a whole, and numbers are replaced after UUIDs and hexadecimal values so
+// that those are not split
+func DefaultNormalization() Normalizer {
+	return ComposeNormalization(
+		NormalizeQuoted(),
+		NormalizeUUIDs(),
+		NormalizePaths(),
+		NormalizeHex(),
+		NormalizeNumbers(),
+		NormalizeIdentifiers(),
+	)
+}
+
+// ComposeNormalization returns a normalizer that applies the given normalizers in order, nil normalizers are skipped
+// and nil is returned when there are no normalizers to apply
+func ComposeNormalization(normalizers ...Normalizer) Normalizer {
+	return composeStrings(normalizers)
+}
+
+// Fingerprint returns a stable hash of the error identifying the problem it reports rather than its occurrence, so
+// that the occurrences of the same problem share the same fingerprint. It is computed from the structure of the chain,
+// the types of its errors, the codes of the registered kinds, see Register, and the messages of the other errors once
+// normalized by the given normalizers, or by DefaultNormalization when none are given, the fingerprint of a nil error
+// is empty.
+func Fingerprint(err error, normalizers ...Normalizer) string {
+	if err == nil {
+		return ""
+	}
+	normalize := ComposeNormalization(normalizers...)
+	if normalize == nil {
+		normalize = DefaultNormalization()
+	}
+	h := sha256.New()
+	walkDepthFirst(err, func(e error, next []error) {
+		_, _ = fmt.Fprintf(h, "%d;", len(next))
+		l, ok := ownLayer(e, next, sanitizeConfig{})
+		if !ok {
+			return
+		}
+		if code := codeOf(l.Original); code != "" {
+			_, _ = fmt.Fprintf(h, "%T;code=%q;", l.Original, code)
+			return
+		}
+		_, _ = fmt.Fprintf(h, "%T;msg=%q;", l.Original, normalize(l.Message))
+	})
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
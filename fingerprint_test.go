@@ -0,0 +1,151 @@
+package gerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var (
+	errFingerprintRead = errors.New("failed to read")
+	errFingerprintCode = DefineKind("fingerprint.read", errors.New("failed to read with code"))
+)
+
+func TestNormalizers(t *testing.T) {
+	tests := []struct {
+		name       string
+		normalizer Normalizer
+		in         string
+		want       string
+	}{
+		{
+			name:       "Quoted",
+			normalizer: NormalizeQuoted(),
+			in:         "cannot open \"a \\\"b\\\" c\" or 'd' or `e`",
+			want:       "cannot open [QUOTED] or [QUOTED] or [QUOTED]",
+		},
+		{
+			name:       "UUIDs",
+			normalizer: NormalizeUUIDs(),
+			in:         "user 123e4567-e89b-12d3-a456-426614174000 not found",
+			want:       "user [UUID] not found",
+		},
+		{
+			name:       "Paths",
+			normalizer: NormalizePaths(),
+			in:         "open /var/lib/app/data.db: no such file, see ./conf/app.yaml ~/app C:\\app\\data.db and http://host/path",
+			want:       "open [PATH]: no such file, see [PATH] [PATH] [PATH] and http://host/path",
+		},
+		{
+			name:       "Hex",
+			normalizer: NormalizeHex(),
+			in:         "bad pointer 0xc000012345 in commit 4f2a9c1b, dead beef deadbeefcafe",
+			want:       "bad pointer [HEX] in commit [HEX], dead beef deadbeefcafe",
+		},
+		{
+			name:       "Numbers",
+			normalizer: NormalizeNumbers(),
+			in:         "retried 3 times in 1.5s, got -1, user42 utf8",
+			want:       "retried [NUMBER] times in [NUMBER]s, got [NUMBER], user42 utf8",
+		},
+		{
+			name:       "Identifiers",
+			normalizer: NormalizeIdentifiers(),
+			in:         "cannot read group someGroup of user42 from DB",
+			want:       "cannot read group [ID] of [ID] from DB",
+		},
+		{
+			name:       "Default",
+			normalizer: DefaultNormalization(),
+			in:         "cannot read 'a b' 123e4567-e89b-12d3-a456-426614174000 /tmp/x 0xff 42 someGroup",
+			want:       "cannot read [QUOTED] [UUID] [PATH] [HEX] [NUMBER] [ID]",
+		},
+		{
+			name:       "Compose",
+			normalizer: ComposeNormalization(nil, NormalizeNumbers(), NormalizePattern(`group \S+`, "group [GROUP]")),
+			in:         "cannot read group admins 42",
+			want:       "cannot read group [GROUP] [NUMBER]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.normalizer(tt.in); got != tt.want {
+				t.Errorf("normalizer() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if ComposeNormalization(nil) != nil {
+		t.Errorf("ComposeNormalization(nil) != nil")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	readGroup := func(group string) error {
+		return New(errFingerprintRead).Add(errors.New("internal error")).Add(fmt.Errorf("cannot read group %v", group))
+	}
+	tests := []struct {
+		name  string
+		a, b  error
+		opts  []Normalizer
+		equal bool
+	}{
+		{
+			name:  "SameProblem",
+			a:     readGroup("someGroup"),
+			b:     readGroup("otherGroup"),
+			equal: true,
+		},
+		{
+			name:  "Numbers",
+			a:     fmt.Errorf("request %d failed: %w", 1, errFingerprintRead),
+			b:     fmt.Errorf("request %d failed: %w", 2, errFingerprintRead),
+			equal: true,
+		},
+		{
+			name:  "Code",
+			a:     New(errFingerprintCode),
+			b:     New(errFingerprintCode),
+			equal: true,
+		},
+		{
+			name: "DifferentKind",
+			a:    New(errFingerprintRead),
+			b:    New(errFingerprintCode),
+		},
+		{
+			name: "DifferentMessage",
+			a:    New(errFingerprintRead).Add(errors.New("disk full")),
+			b:    New(errFingerprintRead).Add(errors.New("permission denied")),
+		},
+		{
+			name: "DifferentStructure",
+			a:    New(errFingerprintRead).Add(errors.New("a")).Add(errors.New("b")),
+			b:    Join(New(errFingerprintRead), New(errors.New("a")), New(errors.New("b"))),
+		},
+		{
+			name: "DifferentType",
+			a:    fmt.Errorf("wrap: %w", errors.New("a")),
+			b:    errors.New("wrap: a"),
+		},
+		{
+			name: "CustomNormalizers",
+			a:    readGroup("someGroup"),
+			b:    readGroup("otherGroup"),
+			opts: []Normalizer{NormalizeNumbers()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := Fingerprint(tt.a, tt.opts...), Fingerprint(tt.b, tt.opts...)
+			if len(a) != 16 || len(b) != 16 {
+				t.Fatalf("Fingerprint() = %q, %q, want 16 hexadecimal digits", a, b)
+			}
+			if (a == b) != tt.equal {
+				t.Errorf("Fingerprint() = %q, %q, want equal %v", a, b, tt.equal)
+			}
+		})
+	}
+	if got := Fingerprint(nil); got != "" {
+		t.Errorf("Fingerprint(nil) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,129 @@
+// Package gerrtest provides assertion helpers for testing code that returns gerr errors, the failures show the chain
+// of the error and, when chains are compared, a diff of the expected and the actual chain.
+package gerrtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/insan1k/gerr"
+)
+
+// AssertIs reports a failure when the error is nil or does not match the target with Is, the target can be a kind, an
+// error of the chain or a gerr.Code, it returns whether the assertion passed
+func AssertIs(t testing.TB, err, target error) bool {
+	t.Helper()
+	if err == nil {
+		t.Errorf("error is nil, want an error matching %v", target)
+		return false
+	}
+	if !gerr.AsGrr(err).Is(target) {
+		t.Errorf("error does not match %v\nerror: %v\nchain:%s", target, err, chainLines(err))
+		return false
+	}
+	return true
+}
+
+// AssertNotIs reports a failure when the error matches the target with Is, a nil error matches no target, it returns
+// whether the assertion passed
+func AssertNotIs(t testing.TB, err, target error) bool {
+	t.Helper()
+	if err != nil && gerr.AsGrr(err).Is(target) {
+		t.Errorf("error matches %v\nerror: %v\nchain:%s", target, err, chainLines(err))
+		return false
+	}
+	return true
+}
+
+// AssertChain reports a failure when the messages of the chain of the error, as returned by Chain of its Grr, differ
+// from the given ones, a nil error has an empty chain, it returns whether the assertion passed
+func AssertChain(t testing.TB, err error, want ...string) bool {
+	t.Helper()
+	got := chainStrings(err)
+	if equal(got, want) {
+		return true
+	}
+	t.Errorf("chain mismatch (-want +got):\n%s", diff(want, got))
+	return false
+}
+
+// AssertKind reports a failure when the error is nil or when the top most layer of its chain, the kind the Grr was
+// created from, does not match the given kind, the kind can be a gerr.Code, it returns whether the assertion passed
+func AssertKind(t testing.TB, err, kind error) bool {
+	t.Helper()
+	if err == nil {
+		t.Errorf("error is nil, want an error of the kind %v", kind)
+		return false
+	}
+	top := gerr.Layers(err)
+	if len(top) == 0 || !gerr.AsGrr(top[0].Original).Is(kind) {
+		t.Errorf("error is not of the kind %v\nerror: %v\nchain:%s", kind, err, chainLines(err))
+		return false
+	}
+	return true
+}
+
+// AssertSanitized reports a failure when the error holds entries of its chain or fields that are not public, that is
+// when sanitizing it would remove anything, a nil error is sanitized, it returns whether the assertion passed
+func AssertSanitized(t testing.TB, err error) bool {
+	t.Helper()
+	if err == nil {
+		return true
+	}
+	g := gerr.AsGrr(err)
+	sanitized := g.Sanitize()
+	var msgs []string
+	if got, want := chainStrings(g), chainStrings(sanitized); !equal(got, want) {
+		msgs = append(msgs, fmt.Sprintf("chain is not sanitized (-sanitized +got):\n%s", diff(want, got)))
+	}
+	var private []string
+	for _, f := range g.Fields() {
+		if !f.Public {
+			private = append(private, f.String())
+		}
+	}
+	if len(private) > 0 {
+		msgs = append(msgs, "fields are not public: "+strings.Join(private, " "))
+	}
+	if len(msgs) > 0 {
+		t.Errorf("%s", strings.Join(msgs, "\n"))
+		return false
+	}
+	return true
+}
+
+// chainStrings returns the messages of the chain of the error
+func chainStrings(err error) []string {
+	if err == nil {
+		return nil
+	}
+	var s []string
+	for _, e := range gerr.AsGrr(err).Chain() {
+		s = append(s, e.Error())
+	}
+	return s
+}
+
+// chainLines returns the messages of the chain of the error, each on its own indented line
+func chainLines(err error) string {
+	var b strings.Builder
+	for _, s := range chainStrings(err) {
+		b.WriteString("\n\t")
+		b.WriteString(s)
+	}
+	return b.String()
+}
+
+// equal reports whether the given slices hold the same strings, nil and empty slices are equal
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,231 @@
+package gerrtest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/insan1k/gerr"
+)
+
+var (
+	errRead    = gerr.DefineKind("gerrtest.read", errors.New("failed to read"))
+	errConnect = errors.New("failed to connect")
+	errParse   = errors.New("failed to parse")
+)
+
+// recorder records the failures reported to it instead of failing the test
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+}
+
+// check runs the assertion against a recorder and compares its result and its failure with the expected ones
+func check(t *testing.T, assert func(t testing.TB) bool, want string) {
+	t.Helper()
+	r := &recorder{TB: t}
+	passed := assert(r)
+	var got string
+	if len(r.failures) > 0 {
+		got = r.failures[0]
+	}
+	if passed != (want == "") || got != want || len(r.failures) > 1 {
+		t.Errorf("passed = %v, failures = %q, want %q", passed, r.failures, want)
+	}
+}
+
+func TestAssertIs(t *testing.T) {
+	err := gerr.New(errRead).Add(errConnect)
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   string
+	}{
+		{name: "Kind", err: err, target: errRead},
+		{name: "Chain", err: err, target: errConnect},
+		{name: "Code", err: err, target: gerr.Code("gerrtest.read")},
+		{name: "Foreign", err: fmt.Errorf("reading: %w", errConnect), target: errConnect},
+		{
+			name:   "Mismatch",
+			err:    err,
+			target: errParse,
+			want:   "error does not match failed to parse\nerror: failed to read failed to connect\nchain:\n\tfailed to read\n\tfailed to connect",
+		},
+		{name: "Nil", target: errRead, want: "error is nil, want an error matching failed to read"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check(t, func(t testing.TB) bool { return AssertIs(t, tt.err, tt.target) }, tt.want)
+		})
+	}
+}
+
+func TestAssertNotIs(t *testing.T) {
+	err := gerr.New(errRead).Add(errConnect)
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   string
+	}{
+		{name: "Mismatch", err: err, target: errParse},
+		{name: "Nil", target: errRead},
+		{
+			name:   "Match",
+			err:    err,
+			target: errConnect,
+			want:   "error matches failed to connect\nerror: failed to read failed to connect\nchain:\n\tfailed to read\n\tfailed to connect",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check(t, func(t testing.TB) bool { return AssertNotIs(t, tt.err, tt.target) }, tt.want)
+		})
+	}
+}
+
+func TestAssertChain(t *testing.T) {
+	err := gerr.New(errRead).Add(errors.New("timeout")).Add(errConnect)
+	tests := []struct {
+		name  string
+		err   error
+		chain []string
+		want  string
+	}{
+		{name: "Equal", err: err, chain: []string{"failed to read", "failed to connect", "timeout"}},
+		{name: "Nil", err: nil},
+		{
+			name:  "Changed",
+			err:   err,
+			chain: []string{"failed to read", "failed to parse", "timeout"},
+			want:  "chain mismatch (-want +got):\n  failed to read\n- failed to parse\n+ failed to connect\n  timeout",
+		},
+		{
+			name:  "Missing",
+			err:   err,
+			chain: []string{"failed to read", "timeout"},
+			want:  "chain mismatch (-want +got):\n  failed to read\n+ failed to connect\n  timeout",
+		},
+		{
+			name:  "Extra",
+			err:   gerr.New(errRead),
+			chain: []string{"failed to read", "failed to connect"},
+			want:  "chain mismatch (-want +got):\n  failed to read\n- failed to connect",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check(t, func(t testing.TB) bool { return AssertChain(t, tt.err, tt.chain...) }, tt.want)
+		})
+	}
+}
+
+func TestAssertKind(t *testing.T) {
+	err := gerr.New(errRead).Add(errConnect)
+	tests := []struct {
+		name string
+		err  error
+		kind error
+		want string
+	}{
+		{name: "Kind", err: err, kind: errRead},
+		{name: "Code", err: err, kind: gerr.Code("gerrtest.read")},
+		{name: "Foreign", err: fmt.Errorf("%w: retrying", errConnect), kind: errConnect},
+		{
+			name: "Chain",
+			err:  err,
+			kind: errConnect,
+			want: "error is not of the kind failed to connect\nerror: failed to read failed to connect\nchain:\n\tfailed to read\n\tfailed to connect",
+		},
+		{name: "Nil", kind: errRead, want: "error is nil, want an error of the kind failed to read"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check(t, func(t testing.TB) bool { return AssertKind(t, tt.err, tt.kind) }, tt.want)
+		})
+	}
+}
+
+func TestAssertSanitized(t *testing.T) {
+	err := gerr.New(errRead, gerr.WithField("user", "bob"), gerr.WithPublicField("id", 1)).
+		Add(errors.New("dial tcp 10.0.0.1")).
+		Add(errConnect, gerr.Public)
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "Sanitized", err: gerr.AsGrr(err).Sanitize()},
+		{name: "Nil"},
+		{
+			name: "Internal",
+			err:  err,
+			want: "chain is not sanitized (-sanitized +got):\n  failed to read\n  failed to connect\n+ dial tcp 10.0.0.1\nfields are not public: user=bob",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check(t, func(t testing.TB) bool { return AssertSanitized(t, tt.err) }, tt.want)
+		})
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	err := gerr.New(errRead, gerr.WithStackTrace(), gerr.WithField("id", 1)).Add(errConnect)
+	joined := gerr.Join(err, gerr.New(errParse, gerr.WithStackTrace()))
+	t.Run("Match", func(t *testing.T) {
+		check(t, func(t testing.TB) bool { return AssertGolden(t, err, "testdata/details.golden") }, "")
+	})
+	t.Run("Joined", func(t *testing.T) {
+		check(t, func(t testing.TB) bool { return AssertGolden(t, joined, "testdata/joined.golden") }, "")
+	})
+	t.Run("Mismatch", func(t *testing.T) {
+		check(t, func(t testing.TB) bool { return AssertGolden(t, gerr.New(errRead), "testdata/details.golden") },
+			"testdata/details.golden mismatch (-want +got):\n- failed to read failed to connect\n- chain:\n- \tfailed to read\n- \tfailed to connect\n- fields: id=1\n+ failed to read\n  ")
+	})
+	t.Run("Update", func(t *testing.T) {
+		t.Setenv(UpdateEnv, "1")
+		path := filepath.Join(t.TempDir(), "golden", "details.golden")
+		check(t, func(t testing.TB) bool { return AssertGolden(t, err, path) }, "")
+		got, readErr := os.ReadFile(path)
+		if readErr != nil || string(got) != Details(err) {
+			t.Errorf("golden file = %q, %v, want %q", got, readErr, Details(err))
+		}
+	})
+}
+
+func TestDetails(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "Nil"},
+		{name: "Kind", err: gerr.New(errRead, gerr.WithStackTrace()), want: "failed to read\n"},
+		{
+			name: "Joined",
+			err:  gerr.Join(gerr.New(errRead, gerr.WithStackTrace()).Add(errConnect), gerr.New(errParse, gerr.WithStackTrace())),
+			want: "failed to read failed to connect\nchain:\n\tfailed to read\n\tfailed to connect\nfailed to parse\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Details(tt.err); got != tt.want {
+				t.Errorf("Details() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
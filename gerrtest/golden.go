@@ -0,0 +1,64 @@
+package gerrtest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// UpdateEnv is the environment variable that, when set to a non empty value, makes AssertGolden write the golden files
+// instead of comparing against them
+const UpdateEnv = "GERRTEST_UPDATE"
+
+// AssertGolden reports a failure when the %+v rendering of the error differs from the content of the golden file at
+// the given path, the stack traces are left out of the rendering since their paths and lines depend on the machine and on
+// the code around the test. The golden file is written instead when the UpdateEnv environment variable is set, it
+// returns whether the assertion passed.
+//
+//	GERRTEST_UPDATE=1 go test ./...
+func AssertGolden(t testing.TB, err error, path string) bool {
+	t.Helper()
+	got := Details(err)
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("creating the directory of the golden file: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("writing the golden file: %v", err)
+		}
+		return true
+	}
+	want, readErr := os.ReadFile(path)
+	if readErr != nil {
+		t.Errorf("reading the golden file, set %s to create it: %v", UpdateEnv, readErr)
+		return false
+	}
+	if string(want) == got {
+		return true
+	}
+	t.Errorf("%s mismatch (-want +got):\n%s", path, diff(strings.Split(string(want), "\n"), strings.Split(got, "\n")))
+	return false
+}
+
+// Details returns the %+v rendering of the error without its stack traces, followed by a new line, the rendering of a
+// nil error is empty
+func Details(err error) string {
+	if err == nil {
+		return ""
+	}
+	lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+	kept := lines[:0]
+	for i := 0; i < len(lines); i++ {
+		if lines[i] != "stack:" {
+			kept = append(kept, lines[i])
+			continue
+		}
+		// each frame is a function line followed by its indented location, the next branch of a joined error follows
+		for i+2 < len(lines) && strings.HasPrefix(lines[i+2], "\t") {
+			i += 2
+		}
+	}
+	return strings.Join(kept, "\n") + "\n"
+}
@@ -0,0 +1,117 @@
+package gerr
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Group aggregates the occurrences of the errors sharing the same fingerprint, see Grouper
+type Group struct {
+	// Fingerprint is the fingerprint shared by the errors of the group, see Fingerprint
+	Fingerprint string
+	// Example is the first error of the group
+	Example error
+	// Count is the amount of errors of the group
+	Count uint64
+	// FirstSeen and LastSeen are the times the first and the last errors of the group were added
+	FirstSeen, LastSeen time.Time
+}
+
+// GroupOpt is the functional type for configuring a Grouper
+type GroupOpt func(g *Grouper)
+
+// WithGroupNormalizers sets the normalizers the fingerprints are computed with, see Fingerprint
+func WithGroupNormalizers(normalizers ...Normalizer) GroupOpt {
+	return func(g *Grouper) {
+		g.normalizers = normalizers
+	}
+}
+
+// WithGroupClock sets the clock the times of the groups are read from, so that they can be tested, the time package
+// by default
+func WithGroupClock(clock Clock) GroupOpt {
+	return func(g *Grouper) {
+		g.clock = clock
+	}
+}
+
+// Grouper aggregates errors into groups by their fingerprint, counting their occurrences and recording when they were
+// first and last seen, it is safe for concurrent use
+type Grouper struct {
+	normalizers []Normalizer
+	clock       Clock
+
+	mu     sync.Mutex
+	groups map[string]*Group
+}
+
+// NewGrouper returns a Grouper configured with the given options
+func NewGrouper(opts ...GroupOpt) *Grouper {
+	g := &Grouper{
+		clock:  realClock{},
+		groups: map[string]*Group{},
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Add adds the error to its group and returns its fingerprint, nil errors are not added and have an empty fingerprint
+func (g *Grouper) Add(err error) string {
+	if err == nil {
+		return ""
+	}
+	fp := Fingerprint(err, g.normalizers...)
+	now := g.clock.Now()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	group, ok := g.groups[fp]
+	if !ok {
+		group = &Group{Fingerprint: fp, Example: err, FirstSeen: now}
+		g.groups[fp] = group
+	}
+	group.Count++
+	group.LastSeen = now
+	return fp
+}
+
+// Group returns the group with the given fingerprint
+func (g *Grouper) Group(fingerprint string) (Group, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	group, ok := g.groups[fingerprint]
+	if !ok {
+		return Group{}, false
+	}
+	return *group, true
+}
+
+// Groups returns the groups, the most frequent first, the groups seen first come first among the equally frequent ones
+func (g *Grouper) Groups() []Group {
+	g.mu.Lock()
+	groups := make([]Group, 0, len(g.groups))
+	for _, group := range g.groups {
+		groups = append(groups, *group)
+	}
+	g.mu.Unlock()
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if !a.FirstSeen.Equal(b.FirstSeen) {
+			return a.FirstSeen.Before(b.FirstSeen)
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+	return groups
+}
+
+// Reset removes every group
+func (g *Grouper) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.groups = map[string]*Group{}
+}
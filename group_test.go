@@ -0,0 +1,66 @@
+package gerr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGrouper(t *testing.T) {
+	clock := newTestClock()
+	start := clock.Now()
+	g := NewGrouper(WithGroupClock(clock))
+	errTimeout := New(errors.New("timeout"))
+	first := fmt.Errorf("cannot read group %s", "someGroup")
+	clock.advance(time.Second)
+	fpRead := g.Add(first)
+	clock.advance(time.Second)
+	g.Add(errTimeout)
+	clock.advance(time.Second)
+	g.Add(fmt.Errorf("cannot read group %s", "otherGroup"))
+	if fp := g.Add(nil); fp != "" {
+		t.Errorf("Add(nil) = %q, want empty", fp)
+	}
+	clock.advance(time.Second)
+	g.Add(fmt.Errorf("cannot read group %s", "fooGroup"))
+	want := []Group{
+		{
+			Fingerprint: fpRead,
+			Example:     first,
+			Count:       3,
+			FirstSeen:   start.Add(time.Second),
+			LastSeen:    start.Add(4 * time.Second),
+		},
+		{
+			Fingerprint: Fingerprint(errTimeout),
+			Example:     errTimeout,
+			Count:       1,
+			FirstSeen:   start.Add(2 * time.Second),
+			LastSeen:    start.Add(2 * time.Second),
+		},
+	}
+	if got := g.Groups(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Groups() = %+v, want %+v", got, want)
+	}
+	if got, ok := g.Group(fpRead); !ok || !reflect.DeepEqual(got, want[0]) {
+		t.Errorf("Group() = %+v, %v, want %+v", got, ok, want[0])
+	}
+	g.Reset()
+	if got := g.Groups(); len(got) != 0 {
+		t.Errorf("Groups() after Reset() = %+v, want none", got)
+	}
+	if _, ok := g.Group(fpRead); ok {
+		t.Errorf("Group() after Reset() found a group")
+	}
+}
+
+func TestGrouperNormalizers(t *testing.T) {
+	g := NewGrouper(WithGroupNormalizers(NormalizeNumbers()))
+	g.Add(errors.New("cannot read group someGroup"))
+	g.Add(errors.New("cannot read group otherGroup"))
+	if got := len(g.Groups()); got != 2 {
+		t.Errorf("len(Groups()) = %d, want 2", got)
+	}
+}
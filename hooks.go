@@ -0,0 +1,152 @@
+package gerr
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Hook is called with the Grr resulting from an operation of this package and the location of the code outside of
+// this package that called it, the Grr is immutable and hooks run synchronously, so they should be quick
+type Hook func(g Grr, caller runtime.Frame)
+
+// hookEvent is the operation a hook subscribes to
+type hookEvent int
+
+const (
+	hookNew hookEvent = iota
+	hookAdd
+	hookSanitize
+	hookAsGrr
+	hookEvents
+)
+
+// hookEntry is a registered hook, the id identifies it to remove it
+type hookEntry struct {
+	id   uint64
+	hook Hook
+}
+
+// _hooks holds the registered hooks of each event, each list is replaced as a whole on every change so that the hooks
+// can be read without locking, and it is nil when there are no hooks so that firing them stays cheap, writes are
+// serialized by _hooksMu
+var (
+	_hooks   [hookEvents]atomic.Pointer[[]hookEntry]
+	_hooksMu sync.Mutex
+	_hookID  uint64
+)
+
+// OnNew registers a hook called with every Grr created by New, Errorf, NewCtx, Decode, Parse or the functions of a
+// Config, it returns a function removing the hook
+func OnNew(h Hook) (remove func()) {
+	return addHook(hookNew, h)
+}
+
+// OnAdd registers a hook called with every Grr an error is added to, by Add, WithErr, WithContext, Annotate or Wrapf,
+// the hooks of the options are called once all the options ran, it returns a function removing the hook
+func OnAdd(h Hook) (remove func()) {
+	return addHook(hookAdd, h)
+}
+
+// OnSanitize registers a hook called with every Grr returned by Sanitize or SanitizeTo, it returns a function removing
+// the hook
+func OnSanitize(h Hook) (remove func()) {
+	return addHook(hookSanitize, h)
+}
+
+// OnAsGrr registers a hook called with every Grr an error that is not a Grr is converted to by AsGrr, it returns a
+// function removing the hook
+func OnAsGrr(h Hook) (remove func()) {
+	return addHook(hookAsGrr, h)
+}
+
+// addHook registers the hook for the given event and returns the function removing it
+func addHook(e hookEvent, h Hook) func() {
+	_hooksMu.Lock()
+	defer _hooksMu.Unlock()
+	_hookID++
+	id := _hookID
+	var hooks []hookEntry
+	if current := _hooks[e].Load(); current != nil {
+		hooks = append(hooks, *current...)
+	}
+	hooks = append(hooks, hookEntry{id: id, hook: h})
+	_hooks[e].Store(&hooks)
+	return func() {
+		removeHook(e, id)
+	}
+}
+
+// removeHook removes the hook with the given id from the given event
+func removeHook(e hookEvent, id uint64) {
+	_hooksMu.Lock()
+	defer _hooksMu.Unlock()
+	current := _hooks[e].Load()
+	if current == nil {
+		return
+	}
+	var hooks []hookEntry
+	for _, h := range *current {
+		if h.id != id {
+			hooks = append(hooks, h)
+		}
+	}
+	if len(hooks) == 0 {
+		_hooks[e].Store(nil)
+		return
+	}
+	_hooks[e].Store(&hooks)
+}
+
+// fire calls the hooks of the given event with the given Grr and returns it, the caller is only looked up when there
+// are hooks
+func fire(e hookEvent, g Grr) Grr {
+	hooks := _hooks[e].Load()
+	if hooks == nil {
+		return g
+	}
+	caller := callerFrame()
+	for _, h := range *hooks {
+		h.hook(g, caller)
+	}
+	return g
+}
+
+// callerFrame returns the first frame of the calling goroutine that does not belong to this package
+func callerFrame() runtime.Frame {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		if !isPackageFrame(f) || !more {
+			return f
+		}
+	}
+}
+
+// addGrr adds the error to the given Grr without firing the hooks when it is a package type
+func addGrr(g Grr, err error, v []Visibility) Grr {
+	switch e := g.(type) {
+	case kind:
+		return e.add(err, v)
+	case wrapped:
+		return e.add(err, v)
+	case joined:
+		return e.add(err, v)
+	}
+	return g.Add(err, v...)
+}
+
+// sanitizeGrr sanitizes the given Grr to the audience without firing the hooks when it is a package type
+func sanitizeGrr(g Grr, audience Visibility) Grr {
+	switch e := g.(type) {
+	case kind:
+		return e.sanitizeTo(audience)
+	case wrapped:
+		return e.sanitizeTo(audience)
+	case joined:
+		return e.sanitizeTo(audience)
+	}
+	return g.SanitizeTo(audience)
+}
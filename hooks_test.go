@@ -0,0 +1,170 @@
+package gerr
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+var errHookRead = errors.New("failed to read")
+
+// hookRecord is an event recorded by the hooks registered by recordHooks
+type hookRecord struct {
+	event  string
+	err    string
+	caller runtime.Frame
+}
+
+// recordHooks registers a hook for every event and returns the recorded events, the hooks are removed when the test
+// ends
+func recordHooks(t *testing.T) *[]hookRecord {
+	var records []hookRecord
+	record := func(event string) Hook {
+		return func(g Grr, caller runtime.Frame) {
+			records = append(records, hookRecord{event: event, err: g.Error(), caller: caller})
+		}
+	}
+	for _, remove := range []func(){
+		OnNew(record("new")),
+		OnAdd(record("add")),
+		OnSanitize(record("sanitize")),
+		OnAsGrr(record("asgrr")),
+	} {
+		t.Cleanup(remove)
+	}
+	return &records
+}
+
+func TestHooks(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func()
+		want []string
+	}{
+		{
+			name: "New",
+			run:  func() { New(errHookRead) },
+			want: []string{"new failed to read"},
+		},
+		{
+			name: "NewWithErr",
+			run:  func() { New(errHookRead, WithErr(errors.New("two"))) },
+			want: []string{"add failed to read two", "new failed to read two"},
+		},
+		{
+			name: "NewWithContext",
+			run: func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				New(errHookRead, WithContext(ctx), WithErr(errors.New("two")))
+			},
+			want: []string{"add failed to read two context canceled", "new failed to read two context canceled"},
+		},
+		{
+			name: "Errorf",
+			run:  func() { Errorf("failed to read %s", "a.txt") },
+			want: []string{"new failed to read a.txt"},
+		},
+		{
+			name: "Config",
+			run:  func() { Config{Separator: ": "}.New(errHookRead).Add(errors.New("two")) },
+			want: []string{"new failed to read", "add failed to read: two"},
+		},
+		{
+			name: "Add",
+			run:  func() { New(errHookRead).Add(errors.New("two")).Add(nil) },
+			want: []string{"new failed to read", "add failed to read two"},
+		},
+		{
+			name: "Sanitize",
+			run:  func() { New(errHookRead).Add(errors.New("two")).Sanitize() },
+			want: []string{"new failed to read", "add failed to read two", "sanitize failed to read"},
+		},
+		{
+			name: "Joined",
+			run:  func() { Join(New(errHookRead), errors.New("two")).Add(errors.New("three")).SanitizeTo(Internal) },
+			want: []string{
+				"new failed to read",
+				"asgrr two",
+				"add failed to read three\ntwo three",
+				"sanitize failed to read three\ntwo three",
+			},
+		},
+		{
+			name: "AsGrr",
+			run:  func() { AsGrr(AsGrr(errHookRead)) },
+			want: []string{"asgrr failed to read"},
+		},
+		{
+			name: "Annotate",
+			run: func() {
+				err := error(New(errHookRead))
+				Annotate(&err, errors.New("two"))
+				Wrapf(&err, "three")
+			},
+			want: []string{"new failed to read", "add two failed to read", "add two three failed to read"},
+		},
+		{
+			name: "Decode",
+			run:  func() { _, _ = Decode([]byte(`{"kind":"failed to read","chain":["two"]}`)) },
+			want: []string{"new failed to read two"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := recordHooks(t)
+			tt.run()
+			var got []string
+			for _, r := range *records {
+				got = append(got, r.event+" "+r.err)
+				if !strings.HasSuffix(r.caller.File, "hooks_test.go") {
+					t.Errorf("%s hook caller = %s:%d, want hooks_test.go", r.event, r.caller.File, r.caller.Line)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("hooks = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHooksRemove(t *testing.T) {
+	var calls int
+	removeFirst := OnNew(func(Grr, runtime.Frame) { calls++ })
+	removeSecond := OnNew(func(Grr, runtime.Frame) { calls += 10 })
+	New(errHookRead)
+	removeFirst()
+	New(errHookRead)
+	removeSecond()
+	removeSecond()
+	New(errHookRead)
+	if calls != 21 {
+		t.Errorf("calls = %d, want 21", calls)
+	}
+	if _hooks[hookNew].Load() != nil {
+		t.Errorf("hooks of New are not nil once every hook is removed")
+	}
+}
+
+func TestHooksAddAfterOptions(t *testing.T) {
+	var got Grr
+	defer OnAdd(func(g Grr, _ runtime.Frame) { got = g })()
+	want := New(errHookRead, WithErr(errors.New("two")), WithField("user", "bob"), WithStackTrace())
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("add hook = %#v, want %#v", got, want)
+	}
+}
+
+func TestHooksImmutable(t *testing.T) {
+	defer OnNew(func(g Grr, _ runtime.Frame) {
+		g.Fields()[0].Value = "changed"
+		g.Add(errors.New("added"))
+	})()
+	got := New(errHookRead, WithField("user", "bob"))
+	if got.Error() != "failed to read" || got.Fields()[0].Value != "bob" {
+		t.Errorf("New() = %v %v, want it unchanged by the hook", got, got.Fields())
+	}
+}
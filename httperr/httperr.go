@@ -0,0 +1,177 @@
+// Package httperr maps the kinds of gerr errors to HTTP status codes and writes them as Problem Details responses, as
+// described by RFC 9457, it also decodes those responses back into gerr errors on the client side.
+package httperr
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/insan1k/gerr"
+)
+
+// ContentType is the media type of Problem Details responses
+const ContentType = "application/problem+json"
+
+// ErrNotProblem is the kind of the error returned by Decode when the response is not a Problem Details response
+var ErrNotProblem = errors.New("httperr: response is not a problem")
+
+// Problem is a Problem Details object as described by RFC 9457, the code and the public fields of the error are added
+// as extension members
+type Problem struct {
+	Type     string       `json:"type,omitempty"`
+	Title    string       `json:"title,omitempty"`
+	Status   int          `json:"status,omitempty"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code,omitempty"`
+	Fields   []gerr.Field `json:"fields,omitempty"`
+}
+
+// Table maps kinds and kind codes to HTTP status codes
+type Table struct {
+	entries    []entry
+	fallback   int
+	typePrefix string
+}
+
+// entry maps a kind, or a gerr.Code standing for the kind registered with it, to a status code
+type entry struct {
+	target error
+	status int
+}
+
+// Option is the functional type for configuring a Table
+type Option func(t *Table)
+
+// WithKind maps the given kind to the given status code
+func WithKind(kind error, status int) Option {
+	return func(t *Table) {
+		t.entries = append(t.entries, entry{target: kind, status: status})
+	}
+}
+
+// WithCode maps the kind registered with the given code to the given status code
+func WithCode(code string, status int) Option {
+	return func(t *Table) {
+		t.entries = append(t.entries, entry{target: gerr.Code(code), status: status})
+	}
+}
+
+// WithDefaultStatus sets the status code used for the errors that are not mapped, http.StatusInternalServerError is
+// used by default
+func WithDefaultStatus(status int) Option {
+	return func(t *Table) {
+		t.fallback = status
+	}
+}
+
+// WithTypePrefix sets the prefix of the type of the problems, the code of the kind is appended to it, when the kind
+// has no code, or no prefix is set, the type is omitted, which stands for "about:blank"
+func WithTypePrefix(prefix string) Option {
+	return func(t *Table) {
+		t.typePrefix = prefix
+	}
+}
+
+// NewTable returns a new Table configured with the given options
+func NewTable(opts ...Option) *Table {
+	t := &Table{fallback: http.StatusInternalServerError}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Status returns the status code mapped to the error, the entries are checked in the order they were added to the table,
+// so that more specific kinds should be added first, the default status is returned when none of them matches the
+// error or any of the errors in its chain
+func (t *Table) Status(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	g := gerr.AsGrr(err)
+	for _, e := range t.entries {
+		if g.Is(e.target) {
+			return e.status
+		}
+	}
+	return t.fallback
+}
+
+// Problem builds the Problem Details object of the error, the error is sanitized first, so that only its kind and its
+// public fields are exposed, the values of the fields are redacted as the detail is, see gerr.RedactedFields, a nil error has no detail and the http.StatusOK status
+func (t *Table) Problem(err error) Problem {
+	status := t.Status(err)
+	if err == nil {
+		return Problem{Title: http.StatusText(status), Status: status}
+	}
+	g := gerr.AsGrr(err).Sanitize()
+	p := Problem{
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: g.Error(),
+		Code:   g.Code(),
+		Fields: gerr.RedactedFields(g),
+	}
+	if t.typePrefix != "" && p.Code != "" {
+		p.Type = t.typePrefix + p.Code
+	}
+	return p
+}
+
+// Write writes the error to the response as a Problem Details response, only the http.StatusOK status is written when
+// the error is nil
+func (t *Table) Write(w http.ResponseWriter, err error) {
+	if err == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	p := t.Problem(err)
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// Decode reads a Problem Details response and converts it back into a gerr error, the kind of the error is the one
+// registered with the code of the problem, see gerr.Register, or an error with the detail of the problem when the code
+// is not registered, the status and the public fields of the problem are attached as public fields, the status of the
+// response is used when the problem has none. An error of the kind ErrNotProblem is returned when the response is not a
+// Problem Details response.
+func Decode(resp *http.Response) (gerr.Grr, error) {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != ContentType {
+		return nil, gerr.New(ErrNotProblem, gerr.WithErrorf("content type %q", mediaType))
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var p Problem
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, gerr.New(ErrNotProblem, gerr.WithErr(err))
+	}
+	if p.Status == 0 {
+		p.Status = resp.StatusCode
+	}
+	return p.Grr(), nil
+}
+
+// Grr converts the problem into a gerr error, see Decode, the status is only attached when the problem has one
+func (p Problem) Grr() gerr.Grr {
+	kind, ok := gerr.Lookup(p.Code)
+	if !ok {
+		detail := p.Detail
+		if detail == "" {
+			detail = p.Title
+		}
+		kind = errors.New(detail)
+	}
+	opts := []gerr.Option{gerr.WithFields(p.Fields...)}
+	if p.Status != 0 {
+		opts = append(opts, gerr.WithPublicField("status", p.Status))
+	}
+	return gerr.New(kind, opts...)
+}
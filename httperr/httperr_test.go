@@ -0,0 +1,259 @@
+package httperr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/insan1k/gerr"
+)
+
+var (
+	errNotFound  = gerr.DefineKind("httperr.not_found", errors.New("not found"))
+	errConflict  = errors.New("conflict")
+	errForbidden = errors.New("forbidden")
+)
+
+func testTable() *Table {
+	return NewTable(
+		WithCode("httperr.not_found", http.StatusNotFound),
+		WithKind(errConflict, http.StatusConflict),
+		WithKind(errForbidden, http.StatusForbidden),
+		WithTypePrefix("https://example.com/problems/"),
+	)
+}
+
+func TestTable_Status(t *testing.T) {
+	tests := []struct {
+		name  string
+		table *Table
+		err   error
+		want  int
+	}{
+		{
+			name:  "Nil",
+			table: testTable(),
+			want:  http.StatusOK,
+		},
+		{
+			name:  "Kind",
+			table: testTable(),
+			err:   gerr.New(errConflict),
+			want:  http.StatusConflict,
+		},
+		{
+			name:  "Code",
+			table: testTable(),
+			err:   gerr.New(errNotFound, gerr.WithErrorf("user %d", 1)),
+			want:  http.StatusNotFound,
+		},
+		{
+			name:  "Wrapped",
+			table: testTable(),
+			err:   gerr.New(errors.New("handler"), gerr.WithErr(errConflict)),
+			want:  http.StatusConflict,
+		},
+		{
+			name:  "TableOrder",
+			table: testTable(),
+			err:   gerr.New(errForbidden, gerr.WithErr(errConflict)),
+			want:  http.StatusConflict,
+		},
+		{
+			name:  "ForeignError",
+			table: testTable(),
+			err:   fmt.Errorf("handler: %w", errConflict),
+			want:  http.StatusConflict,
+		},
+		{
+			name:  "Unmapped",
+			table: testTable(),
+			err:   errors.New("unmapped"),
+			want:  http.StatusInternalServerError,
+		},
+		{
+			name:  "DefaultStatus",
+			table: NewTable(WithDefaultStatus(http.StatusBadGateway)),
+			err:   errors.New("unmapped"),
+			want:  http.StatusBadGateway,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.table.Status(tt.err); got != tt.want {
+				t.Errorf("Status() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_Problem(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Problem
+	}{
+		{
+			name: "Sanitized",
+			err: gerr.New(errNotFound,
+				gerr.WithErrorf("user %d", 1),
+				gerr.WithPublicField("resource", "user"),
+				gerr.WithField("query", "select"),
+			),
+			want: Problem{
+				Type:   "https://example.com/problems/httperr.not_found",
+				Title:  "Not Found",
+				Status: http.StatusNotFound,
+				Detail: "not found",
+				Code:   "httperr.not_found",
+				Fields: []gerr.Field{{Key: "resource", Value: "user", Public: true}},
+			},
+		},
+		{
+			name: "Redacted",
+			err:  gerr.New(errConflict, gerr.WithRedaction(gerr.DefaultRedaction()), gerr.WithPublicField("user", "bob@example.com")),
+			want: Problem{
+				Title:  "Conflict",
+				Status: http.StatusConflict,
+				Detail: "conflict",
+				Fields: []gerr.Field{{Key: "user", Value: "[EMAIL]", Public: true}},
+			},
+		},
+		{
+			name: "Nil",
+			want: Problem{Title: "OK", Status: http.StatusOK},
+		},
+		{
+			name: "NoCode",
+			err:  gerr.New(errConflict),
+			want: Problem{
+				Title:  "Conflict",
+				Status: http.StatusConflict,
+				Detail: "conflict",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testTable().Problem(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Problem() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantKind   error
+		wantError  string
+		wantFields []gerr.Field
+	}{
+		{
+			name:       "RegisteredKind",
+			err:        gerr.New(errNotFound, gerr.WithErrorf("user %d", 1), gerr.WithPublicField("resource", "user")),
+			wantStatus: http.StatusNotFound,
+			wantKind:   errNotFound,
+			wantError:  "not found",
+			wantFields: []gerr.Field{
+				{Key: "resource", Value: "user", Public: true},
+				{Key: "status", Value: http.StatusNotFound, Public: true},
+			},
+		},
+		{
+			name:       "UnregisteredKind",
+			err:        gerr.New(errConflict, gerr.WithField("query", "select")),
+			wantStatus: http.StatusConflict,
+			wantError:  "conflict",
+			wantFields: []gerr.Field{{Key: "status", Value: http.StatusConflict, Public: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				testTable().Write(w, tt.err)
+			}))
+			defer srv.Close()
+			resp, err := http.Get(srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("StatusCode = %v, want %v", resp.StatusCode, tt.wantStatus)
+			}
+			got, err := Decode(resp)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if got.Error() != tt.wantError {
+				t.Errorf("Error() = %v, want %v", got.Error(), tt.wantError)
+			}
+			if tt.wantKind != nil && !got.Is(tt.wantKind) {
+				t.Errorf("Is(%v) = false, want true", tt.wantKind)
+			}
+			if fields := got.Fields(); !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("Fields() = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestWrite_Nil(t *testing.T) {
+	rec := httptest.NewRecorder()
+	testTable().Write(rec, nil)
+	if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Errorf("Write() = %v %q, want %v and no body", rec.Code, rec.Body.String(), http.StatusOK)
+	}
+}
+
+func TestDecode_MissingStatus(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", ContentType)
+	rec.WriteHeader(http.StatusConflict)
+	_, _ = rec.WriteString(`{"detail":"conflict"}`)
+	got, err := Decode(rec.Result())
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	want := []gerr.Field{{Key: "status", Value: http.StatusConflict, Public: true}}
+	if fields := got.Fields(); !reflect.DeepEqual(fields, want) {
+		t.Errorf("Fields() = %v, want %v", fields, want)
+	}
+}
+
+func TestDecode_NotProblem(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{
+			name:        "ContentType",
+			contentType: "application/json",
+			body:        `{"status":500}`,
+		},
+		{
+			name:        "Body",
+			contentType: ContentType,
+			body:        `{`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			rec.Header().Set("Content-Type", tt.contentType)
+			rec.WriteHeader(http.StatusInternalServerError)
+			_, _ = rec.WriteString(tt.body)
+			_, err := Decode(rec.Result())
+			if !errors.Is(err, ErrNotProblem) {
+				t.Errorf("Decode() error = %v, want %v", err, ErrNotProblem)
+			}
+		})
+	}
+}
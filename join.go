@@ -0,0 +1,220 @@
+package gerr
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// joinSeparator is the separator between the errors of a joined error, the same one used by errors.Join
+const joinSeparator = "\n"
+
+// Join returns a Grr that wraps the given errors, each of them converted to the package type Grr, nil errors are
+// discarded and nil is returned if there are no errors to join. Joined errors are errors themselves, Join flattens them
+// so that all the branches are at the same level.
+func Join(errs ...error) Grr {
+	var branches []Grr
+	for _, err := range errs {
+		branches = appendBranch(branches, err)
+	}
+	if len(branches) == 0 {
+		return nil
+	}
+	return newJoined(branches)
+}
+
+// appendBranch appends the given error to the branches converting it to the package type Grr, the branches of joined
+// errors, and of the errors that only join other errors as errors.Join does, are appended individually
+func appendBranch(branches []Grr, err error) []Grr {
+	if err == nil {
+		return branches
+	}
+	g := AsGrr(err)
+	if j, ok := g.(joined); ok {
+		return append(branches, j.errs...)
+	}
+	return append(branches, g)
+}
+
+// joinedErrors returns the errors wrapped by the given error when it wraps multiple errors without a message of its
+// own, as errors.Join does
+func joinedErrors(err error) ([]error, bool) {
+	if _, ok := err.(interface {
+		Unwrap() []error
+	}); !ok {
+		return nil, false
+	}
+	errs := unwrapAll(err)
+	if _, pure := ownMessages(err, errs); !pure {
+		return nil, false
+	}
+	return errs, true
+}
+
+// joinBranches converts the given errors to branches configured with the given defaults, the branches of joined errors,
+// and of the errors that only join other errors, are converted individually
+func joinBranches(errs []error, defaults kind) []Grr {
+	var branches []Grr
+	for _, err := range errs {
+		if j, ok := err.(joined); ok {
+			branches = append(branches, j.errs...)
+			continue
+		}
+		if g, ok := err.(Grr); ok {
+			branches = append(branches, g)
+			continue
+		}
+		if sub, ok := joinedErrors(err); ok {
+			branches = append(branches, joinBranches(sub, defaults)...)
+			continue
+		}
+		branches = append(branches, newWrappedFromWrappedError(err, defaults).grr())
+	}
+	return branches
+}
+
+// joined is the package type that holds multiple Grr as branches, all of its functions operate across all the branches,
+// the branches are held behind a pointer so that joined stays comparable
+type joined struct {
+	*branches
+}
+
+// branches are the errors of a joined error, they are never modified in place
+type branches struct {
+	errs []Grr
+}
+
+// newJoined returns a joined error with the given branches
+func newJoined(errs []Grr) joined {
+	return joined{&branches{errs: errs}}
+}
+
+// Add adds the given error to the error chain of every branch
+func (j joined) Add(err error, v ...Visibility) Grr {
+	if err == nil {
+		return j
+	}
+	return fire(hookAdd, j.add(err, v))
+}
+
+// add adds the given error to the error chain of every branch without firing the hooks
+func (j joined) add(err error, v []Visibility) joined {
+	if err == nil {
+		return j
+	}
+	errs := make([]Grr, len(j.errs))
+	for i, g := range j.errs {
+		errs[i] = addGrr(g, err, v)
+	}
+	return newJoined(errs)
+}
+
+// Append returns a new joined error with the given errors added as branches
+func (j joined) Append(errs ...error) Grr {
+	branches := append([]Grr(nil), j.errs...)
+	for _, err := range errs {
+		branches = appendBranch(branches, err)
+	}
+	return newJoined(branches)
+}
+
+// Error implements the error interface, the error of each branch is written on its own line
+func (j joined) Error() string {
+	s := make([]string, len(j.errs))
+	for i, g := range j.errs {
+		s[i] = g.Error()
+	}
+	return strings.Join(s, joinSeparator)
+}
+
+// Chain returns the chains of all the branches, one after the other, as a single slice of errors
+func (j joined) Chain() []error {
+	var chain []error
+	for _, g := range j.errs {
+		chain = append(chain, g.Chain()...)
+	}
+	return chain
+}
+
+// Is returns true if the error is or exists in the chain of any of the branches
+func (j joined) Is(err error) bool {
+	for _, g := range j.errs {
+		if g.Is(err) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sanitize sanitizes every branch
+func (j joined) Sanitize() Grr {
+	return j.SanitizeTo(Public)
+}
+
+// SanitizeTo sanitizes every branch to the given audience
+func (j joined) SanitizeTo(audience Visibility) Grr {
+	return fire(hookSanitize, j.sanitizeTo(audience))
+}
+
+// sanitizeTo sanitizes every branch to the given audience without firing the hooks
+func (j joined) sanitizeTo(audience Visibility) joined {
+	errs := make([]Grr, len(j.errs))
+	for i, g := range j.errs {
+		errs[i] = sanitizeGrr(g, audience)
+	}
+	return newJoined(errs)
+}
+
+// Code returns the code of the first branch that has one
+func (j joined) Code() string {
+	for _, g := range j.errs {
+		if c := g.Code(); c != "" {
+			return c
+		}
+	}
+	return ""
+}
+
+// StackTrace returns the stack trace of the first branch that has one
+func (j joined) StackTrace() []runtime.Frame {
+	for _, g := range j.errs {
+		if st := g.StackTrace(); st != nil {
+			return st
+		}
+	}
+	return nil
+}
+
+// Fields returns the fields of all the branches, a field replaces the value of the fields of previous branches with
+// the same key
+func (j joined) Fields() []Field {
+	var fields []Field
+	for _, g := range j.errs {
+		fields = mergeFields(fields, g.Fields())
+	}
+	return fields
+}
+
+// Format implements the fmt.Formatter interface, the %+v verb formats every branch with its chain, fields and stack
+// trace
+func (j joined) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		for i, g := range j.errs {
+			if i > 0 {
+				_, _ = fmt.Fprint(s, joinSeparator)
+			}
+			_, _ = fmt.Fprintf(s, "%+v", g)
+		}
+		return
+	}
+	format(s, verb, j, nil, nil)
+}
+
+// Unwrap implements the Unwrap interface for errors that wrap multiple errors
+func (j joined) Unwrap() []error {
+	errs := make([]error, len(j.errs))
+	for i, g := range j.errs {
+		errs[i] = g
+	}
+	return errs
+}
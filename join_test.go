@@ -0,0 +1,155 @@
+package gerr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	one, two, three := errors.New("one"), errors.New("two"), errors.New("three")
+	sep := _separator()
+	tests := []struct {
+		name      string
+		got       Grr
+		wantNil   bool
+		wantError string
+		wantChain []error
+		wantIs    []error
+		wantNotIs []error
+	}{
+		{
+			name:    "Empty",
+			got:     Join(nil, nil),
+			wantNil: true,
+		},
+		{
+			name:      "Join",
+			got:       Join(New(one).Add(two), three),
+			wantError: "one" + sep + "two\nthree",
+			wantChain: []error{one, two, three},
+			wantIs:    []error{one, two, three},
+		},
+		{
+			name:      "Append",
+			got:       New(one).Append(New(two), nil, three),
+			wantError: "one\ntwo\nthree",
+			wantChain: []error{one, two, three},
+			wantIs:    []error{one, two, three},
+		},
+		{
+			name:      "AppendFlattens",
+			got:       Join(one, two).Append(Join(three)),
+			wantError: "one\ntwo\nthree",
+			wantChain: []error{one, two, three},
+			wantIs:    []error{one, two, three},
+		},
+		{
+			name:      "Add",
+			got:       Join(one, two).Add(three),
+			wantError: "one" + sep + "three\ntwo" + sep + "three",
+			wantChain: []error{one, three, two, three},
+			wantIs:    []error{one, two, three},
+		},
+		{
+			name:      "Sanitize",
+			got:       Join(New(one).Add(three), New(two).Add(three)).Sanitize(),
+			wantError: "one\ntwo",
+			wantChain: []error{one, two},
+			wantIs:    []error{one, two},
+			wantNotIs: []error{three},
+		},
+		{
+			name:      "AsGrrStdlibJoin",
+			got:       AsGrr(errors.Join(one, errors.Join(two, three))),
+			wantError: "one\ntwo\nthree",
+			wantChain: []error{one, two, three},
+			wantIs:    []error{one, two, three},
+		},
+		{
+			name:      "NewStdlibJoin",
+			got:       New(errors.Join(one, two), WithField("user", "someUser")),
+			wantError: "one\ntwo",
+			wantChain: []error{one, two},
+			wantIs:    []error{one, two},
+			wantNotIs: []error{three},
+		},
+		{
+			name:      "NewJoin",
+			got:       Config{Separator: sep}.New(Join(one, two)).Add(three),
+			wantError: "one" + sep + "three\ntwo" + sep + "three",
+			wantChain: []error{one, three, two, three},
+			wantIs:    []error{one, two, three},
+		},
+		{
+			name:      "AsGrrMultipleWrapped",
+			got:       AsGrr(fmt.Errorf("x %w and %w", one, two)),
+			wantError: "x and" + sep + "one\ntwo",
+			wantChain: []error{errors.New("x and"), one, two},
+			wantIs:    []error{one, two},
+			wantNotIs: []error{three},
+		},
+		{
+			name:      "AppendStdlibJoin",
+			got:       New(one).Append(errors.Join(New(two).Add(three), nil)).Sanitize(),
+			wantError: "one\ntwo",
+			wantChain: []error{one, two},
+			wantIs:    []error{one, two},
+			wantNotIs: []error{three},
+		},
+		{
+			name:      "SanitizeMultipleWrapped",
+			got:       New(one).Add(fmt.Errorf("x %w and %w", two, three)).Sanitize(),
+			wantError: "one",
+			wantChain: []error{one},
+			wantIs:    []error{one},
+			wantNotIs: []error{two, three},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantNil {
+				if tt.got != nil {
+					t.Errorf("Join() = %v, want nil", tt.got)
+				}
+				return
+			}
+			if got := tt.got.Error(); got != tt.wantError {
+				t.Errorf("Error() = %q, want %q", got, tt.wantError)
+			}
+			if got := tt.got.Chain(); !reflect.DeepEqual(got, tt.wantChain) {
+				t.Errorf("Chain() = %v, want %v", got, tt.wantChain)
+			}
+			for _, target := range tt.wantIs {
+				if !tt.got.Is(target) || !errors.Is(tt.got, target) {
+					t.Errorf("Is(%v) = false, want true", target)
+				}
+			}
+			for _, target := range tt.wantNotIs {
+				if tt.got.Is(target) || errors.Is(tt.got, target) {
+					t.Errorf("Is(%v) = true, want false", target)
+				}
+			}
+		})
+	}
+}
+
+func TestJoinAs(t *testing.T) {
+	var target exampleTypedError
+	err := Join(errors.New("one"), New(errors.New("two")).Add(exampleTypedError{code: 2}))
+	if !errors.As(err, &target) || target.code != 2 {
+		t.Errorf("errors.As() = %v, want %v", target, exampleTypedError{code: 2})
+	}
+}
+
+func TestJoinFields(t *testing.T) {
+	err := Join(
+		New(errors.New("one"), WithField("user", "someUser"), WithField("group", "someGroup")),
+		New(errors.New("two"), WithField("group", "otherGroup")),
+	)
+	want := []Field{{Key: "user", Value: "someUser"}, {Key: "group", Value: "otherGroup"}}
+	if got := err.Fields(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Fields() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,181 @@
+package gerr
+
+import (
+	"encoding/json"
+)
+
+// jsonGrr is the stable schema of the package types when marshalled to JSON, the chain holds the messages of the errors
+// below the kind, top most first, while joined errors hold their branches in errors, as do the errors whose bottom most
+// chain entry joins multiple errors, when the separator is missing the one of the global configuration is used
+type jsonGrr struct {
+	Kind      string    `json:"kind,omitempty"`
+	Code      string    `json:"code,omitempty"`
+	Chain     []string  `json:"chain,omitempty"`
+	Separator *string   `json:"separator,omitempty"`
+	Fields    []Field   `json:"fields,omitempty"`
+	Errors    []jsonGrr `json:"errors,omitempty"`
+}
+
+// Decode reconstructs a Grr from the JSON produced by marshalling a Grr, the errors of the chain are replaced by the
+// locally registered kinds that carry the same message, see RegisterKinds, while the kind is replaced by the one
+// registered with its code when there is one, see Register, the given options are applied to the result
+func Decode(data []byte, opts ...Option) (Grr, error) {
+	var j jsonGrr
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, err
+	}
+	return j.grr(opts...), nil
+}
+
+// toJSON converts the error to the schema used to marshal it to JSON
+func (k kind) toJSON() jsonGrr {
+	e := k.ext()
+	return jsonGrr{
+		Kind:      e.redact.redacted(kindMessage(k.err)),
+		Code:      k.Code(),
+		Separator: &k.separator,
+		Fields:    e.redact.fields(e.fields),
+	}
+}
+
+// toJSON converts the error to the schema used to marshal it to JSON, when the bottom most entry of the chain joins
+// multiple errors its branches are held in errors instead of being flattened into the chain
+func (w wrapped) toJSON() jsonGrr {
+	j := w.kind.toJSON()
+	chain := w.Chain()[1:]
+	branches, errs := w.bottomBranches()
+	for _, err := range errs {
+		chain = chain[:len(chain)-len(Chain(err))]
+	}
+	for _, err := range chain {
+		j.Chain = append(j.Chain, err.Error())
+	}
+	for _, b := range branches {
+		j.Errors = append(j.Errors, grrToJSON(b))
+	}
+	return j
+}
+
+// bottomBranches returns the branches of the bottom most entry of the chain when it joins multiple errors, along with
+// the errors they were converted from, the branches that are not package types are converted with the separator and
+// the redaction rule of the kind
+func (w wrapped) bottomBranches() ([]Grr, []error) {
+	err := w.err
+	for {
+		l, ok := err.(layer)
+		if !ok || l.next == nil {
+			break
+		}
+		err = l.next
+	}
+	if j, ok := err.(joined); ok {
+		errs := make([]error, len(j.errs))
+		for i, b := range j.errs {
+			errs[i] = b
+		}
+		return j.errs, errs
+	}
+	if _, ok := err.(interface{ Unwrap() []error }); !ok {
+		return nil, nil
+	}
+	errs := unwrapAll(err)
+	defaults := kind{separator: w.kind.separator}.withExtras(func(e *kindExtras) {
+		e.redact = w.kind.ext().redact
+	})
+	return joinBranches(errs, defaults), errs
+}
+
+// toJSON converts the error to the schema used to marshal it to JSON, branches that are not package types are
+// converted to them
+func (j joined) toJSON() jsonGrr {
+	var jg jsonGrr
+	for _, g := range j.errs {
+		jg.Errors = append(jg.Errors, grrToJSON(g))
+	}
+	return jg
+}
+
+// grrToJSON converts the given Grr to the schema used to marshal it to JSON
+func grrToJSON(g Grr) jsonGrr {
+	switch e := g.(type) {
+	case kind:
+		return e.toJSON()
+	case wrapped:
+		return e.toJSON()
+	case joined:
+		return e.toJSON()
+	}
+	return jsonGrr{Kind: g.Error()}
+}
+
+// kindMessage returns the message of the error of a kind
+func kindMessage(err error) string {
+	if l, ok := err.(layer); ok {
+		return l.text()
+	}
+	return err.Error()
+}
+
+// grr reconstructs the Grr from the schema, the given options are applied to the result, errors without a kind are
+// joined errors while the errors of a kind are the branches joined at the bottom of its chain
+func (j jsonGrr) grr(opts ...Option) Grr {
+	if len(j.Errors) > 0 && j.Kind == "" && j.Code == "" {
+		errs := make([]error, len(j.Errors))
+		for i, e := range j.Errors {
+			errs[i] = e.grr(opts...)
+		}
+		return Join(errs...)
+	}
+	c := DefaultConfig()
+	if j.Separator != nil {
+		c.Separator = *j.Separator
+	}
+	var chainOpts []Option
+	if len(j.Errors) > 0 {
+		chainOpts = append(chainOpts, chainErr(jsonGrr{Errors: j.Errors}.grr()))
+	}
+	for i := len(j.Chain) - 1; i >= 0; i-- {
+		chainOpts = append(chainOpts, chainErr(lookupKind(j.Chain[i])))
+	}
+	chainOpts = append(chainOpts, WithFields(j.Fields...))
+	k, ok := lookupCode(j.Code)
+	if !ok {
+		k = lookupKind(j.Kind)
+	}
+	return c.New(k, append(chainOpts, opts...)...)
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (k kind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.toJSON())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (k *kind) UnmarshalJSON(data []byte) error {
+	g, err := Decode(data)
+	if err != nil {
+		return err
+	}
+	*k = newWrappedFromWrappedError(g, kind{}).kind
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (w wrapped) MarshalJSON() ([]byte, error) {
+	return json.Marshal(w.toJSON())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (w *wrapped) UnmarshalJSON(data []byte) error {
+	g, err := Decode(data)
+	if err != nil {
+		return err
+	}
+	*w = newWrappedFromWrappedError(g, kind{})
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (j joined) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.toJSON())
+}
@@ -0,0 +1,165 @@
+package gerr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+var errJSONRegistered = errors.New("registered kind")
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Grr
+		want string
+	}{
+		{
+			name: "Kind",
+			got:  New(errors.New("one"), WithSeparator(": ")),
+			want: `{"kind":"one","separator":": "}`,
+		},
+		{
+			name: "Wrapped",
+			got:  New(errors.New("one"), WithSeparator(": "), WithPublicField("user", "someUser")).Add(errors.New("two")).Add(errors.New("three")),
+			want: `{"kind":"one","chain":["three","two"],"separator":": ","fields":[{"key":"user","value":"someUser","public":true}]}`,
+		},
+		{
+			name: "WrappedForeign",
+			got:  Config{Separator: ": "}.New(fmt.Errorf("one: %w", fmt.Errorf("two: %w", errors.New("three")))),
+			want: `{"kind":"one","chain":["two","three"],"separator":": "}`,
+		},
+		{
+			name: "Joined",
+			got:  Join(New(errors.New("one"), WithSeparator(": ")), New(errors.New("two"), WithSeparator(": ")).Add(errors.New("three"))),
+			want: `{"errors":[{"kind":"one","separator":": "},{"kind":"two","chain":["three"],"separator":": "}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.got)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	RegisterKinds(errJSONRegistered)
+	original := New(errJSONRegistered, WithSeparator(": "), WithField("user", "someUser")).
+		Add(errors.New("two")).
+		Add(errors.New("three"))
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	got, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got.Error() != original.Error() {
+		t.Errorf("Decode().Error() = %v, want %v", got, original)
+	}
+	if !got.Is(errJSONRegistered) || !errors.Is(got, errJSONRegistered) {
+		t.Errorf("Decode().Is() = false, want true for a registered kind")
+	}
+	if got.Is(errors.New("two")) {
+		t.Errorf("Decode().Is() = true, want false for an unregistered error matched by identity")
+	}
+	if !reflect.DeepEqual(got.Fields(), original.Fields()) {
+		t.Errorf("Decode().Fields() = %v, want %v", got.Fields(), original.Fields())
+	}
+	if got, _ := Decode(data, WithStringMatching()); !got.Is(errors.New("two")) {
+		t.Errorf("Decode().Is() = false, want true when matching by string")
+	}
+	if _, err := Decode([]byte("{")); err == nil {
+		t.Errorf("Decode() error = nil, want an error for invalid JSON")
+	}
+}
+
+func TestDecodeJoined(t *testing.T) {
+	RegisterKinds(errJSONRegistered)
+	data, _ := json.Marshal(Join(errors.New("one"), New(errJSONRegistered).Add(errors.New("two"))))
+	got, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if want := "one\nregistered kind" + _separator() + "two"; got.Error() != want {
+		t.Errorf("Decode().Error() = %q, want %q", got, want)
+	}
+	if !got.Is(errJSONRegistered) {
+		t.Errorf("Decode().Is() = false, want true for a registered kind")
+	}
+}
+
+func TestDecodeJoinedChain(t *testing.T) {
+	RegisterKinds(errJSONRegistered)
+	two := errors.New("two")
+	tests := []struct {
+		name      string
+		original  Grr
+		wantJSON  string
+		wantError string
+	}{
+		{
+			name:      "New",
+			original:  New(fmt.Errorf("multi %w and %w", errJSONRegistered, two)),
+			wantJSON:  `{"kind":"multi and","separator":" ","errors":[{"kind":"registered kind","separator":" "},{"kind":"two","separator":" "}]}`,
+			wantError: "multi and registered kind\ntwo",
+		},
+		{
+			name:      "WithErr",
+			original:  New(errors.New("one"), WithErr(fmt.Errorf("x %w and %w", errJSONRegistered, two))),
+			wantJSON:  `{"kind":"one","chain":["x and"],"separator":" ","errors":[{"kind":"registered kind","separator":" "},{"kind":"two","separator":" "}]}`,
+			wantError: "one x and registered kind\ntwo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.original)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(data) != tt.wantJSON {
+				t.Errorf("json.Marshal() = %s, want %s", data, tt.wantJSON)
+			}
+			got, err := Decode(data)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if got.Error() != tt.wantError {
+				t.Errorf("Decode().Error() = %q, want %q", got, tt.wantError)
+			}
+			if !got.Is(errJSONRegistered) {
+				t.Errorf("Decode().Is() = false, want true for a registered kind of a branch")
+			}
+			if again, _ := json.Marshal(got); string(again) != tt.wantJSON {
+				t.Errorf("json.Marshal(Decode()) = %s, want %s", again, tt.wantJSON)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	data, _ := json.Marshal(New(errors.New("one"), WithSeparator(": ")).Add(errors.New("two")))
+	var w wrapped
+	if err := json.Unmarshal(data, &w); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if w.Error() != "one: two" {
+		t.Errorf("json.Unmarshal() = %v, want %v", w, "one: two")
+	}
+	var k kind
+	if err := json.Unmarshal(data, &k); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if k.Error() != "one" {
+		t.Errorf("json.Unmarshal() = %v, want %v", k, "one")
+	}
+}
@@ -1,34 +1,182 @@
 package gerr
 
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
 // kind represents the type of error and does not contain any additional context to the error
 type kind struct {
 	err       error
 	separator string
+	match     matchMode
+	stack     *stack
+	// extras holds the optional configuration of the kind behind a pointer so that the kind stays comparable, see
+	// kindExtras
+	extras *kindExtras
 }
 
-func (k kind) Sanitize() Grr {
+// kindExtras is the optional configuration of a kind, it is never modified in place, changes are made on a copy, see
+// withExtras
+type kindExtras struct {
+	// fields are the key value pairs attached to the error, shared between copies and never modified in place
+	fields       []Field
+	renderFields bool
+	// redact redacts the messages of the error, see RedactRule
+	redact RedactRule
+	// traits classify the error to decide whether it can be retried, see Trait
+	traits Trait
+}
+
+// ext returns the extras of the kind, or the zero extras when none were set
+func (k kind) ext() kindExtras {
+	if k.extras == nil {
+		return kindExtras{}
+	}
+	return *k.extras
+}
+
+// withExtras returns the kind with the given change applied to a copy of its extras
+func (k kind) withExtras(change func(e *kindExtras)) kind {
+	e := k.ext()
+	change(&e)
+	k.extras = &e
 	return k
 }
 
+// Sanitize removes all additional context from the Grr that is not public, see SanitizeTo
+func (k kind) Sanitize() Grr {
+	return k.SanitizeTo(Public)
+}
+
+// SanitizeTo removes the fields that are not visible to the given audience, the kind itself is always kept
+func (k kind) SanitizeTo(audience Visibility) Grr {
+	return fire(hookSanitize, k.sanitizeTo(audience))
+}
+
+// sanitizeTo removes the fields that are not visible to the given audience without firing the hooks
+func (k kind) sanitizeTo(audience Visibility) kind {
+	if len(k.ext().fields) == 0 {
+		return k
+	}
+	return k.withExtras(func(e *kindExtras) {
+		e.fields = sanitizeFields(e.fields, audience)
+	})
+}
+
 // Grr implements the error interface
 func (k kind) Error() string {
-	return k.err.Error()
+	return k.ext().redact.redacted(k.err.Error() + k.fieldsString())
+}
+
+// Fields returns the fields attached to the error
+func (k kind) Fields() []Field {
+	return copyFields(k.ext().fields)
+}
+
+// fieldsString returns the fields to be appended to the string returned by Error, if rendering them was enabled
+func (k kind) fieldsString() string {
+	e := k.ext()
+	if !e.renderFields {
+		return ""
+	}
+	return fieldsString(e.fields)
 }
 
 // Add adds the given error to the error chain and returns the error as the package type Wrapped
-func (k kind) Add(err error) Grr {
+func (k kind) Add(err error, v ...Visibility) Grr {
+	g := k.add(err, v)
+	if err != nil {
+		fire(hookAdd, g)
+	}
+	return g
+}
+
+// add adds the given error to the error chain without firing the hooks
+func (k kind) add(err error, v []Visibility) wrapped {
+	vis := visibilityOf(v)
+	if err != nil && (shouldCaptureStack(k) || vis != Internal) {
+		l := layer{
+			orig:       err,
+			separator:  k.separator,
+			visibility: vis,
+		}
+		if shouldCaptureStack(k) {
+			l.stack = callers()
+		}
+		err = l
+	}
 	return wrapped{
 		kind: k,
 		err:  err,
 	}
 }
 
-// Is returns true if the error is of the given type, or exists in its chain
+// Append joins the given errors with the kind, returning a Grr that holds all of them as branches
+func (k kind) Append(errs ...error) Grr {
+	return Join(append([]error{k}, errs...)...)
+}
+
+// Is returns true if the error is of the given type, or exists in its chain, errors are matched by identity unless
+// string matching was enabled
 func (k kind) Is(err error) bool {
-	return k.err.Error() == err.Error()
+	if err == nil {
+		return false
+	}
+	err, ok := codeTarget(err)
+	if !ok {
+		return false
+	}
+	if k.match == matchString {
+		return k.err.Error() == err.Error()
+	}
+	if t, ok := err.(kind); ok {
+		err = t.err
+	}
+	return errors.Is(k.err, err)
+}
+
+// As finds the first error in the kind that matches target, and if one is found, sets target to that error value
+func (k kind) As(target any) bool {
+	return errors.As(k.err, target)
+}
+
+// Code returns the code the kind was registered with, or an empty string if it was not registered
+func (k kind) Code() string {
+	return codeOf(k.value())
+}
+
+// StackTrace returns the stack trace captured when the error was created, or nil when none was captured
+func (k kind) StackTrace() []runtime.Frame {
+	return k.stack.frames()
 }
 
-// Chain returns the error chain as a slice of errors
+// Format implements the fmt.Formatter interface, the %+v verb includes the chain, the fields and the stack trace
+func (k kind) Format(s fmt.State, verb rune) {
+	format(s, verb, k, k.stack, k.ext().redact)
+}
+
+// Chain returns the error chain as a slice of errors, the errors whose message is redacted still unwrap to the original
+// errors
 func (k kind) Chain() []error {
-	return Chain(k, WithTrimCustom(k.separator))
+	return k.ext().redact.chain(Chain(k, WithTrimCustom(k.separator)))
+}
+
+// layer returns the kind as an entry of the structural chain, its message redacted by the rule of the kind
+func (k kind) layer() Layer {
+	l := Layer{Message: k.err.Error(), Original: k.err, Exact: true}
+	if e, ok := k.err.(layer); ok {
+		l = e.layer()
+	}
+	l.Message = k.ext().redact.redacted(l.Message)
+	return l
+}
+
+// value returns the error that represents the kind in a chain
+func (k kind) value() error {
+	if l, ok := k.err.(layer); ok {
+		return l.value()
+	}
+	return k.err
 }
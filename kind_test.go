@@ -119,7 +119,9 @@ func Test_kind_Is(t *testing.T) {
 	type fields struct {
 		err       error
 		separator string
+		match     matchMode
 	}
+	sentinel := errors.New("test")
 	type args struct {
 		err error
 	}
@@ -131,9 +133,43 @@ func Test_kind_Is(t *testing.T) {
 	}{
 		{
 			name: "Is",
+			fields: fields{
+				err:       sentinel,
+				separator: _separator(),
+			},
+			args: args{
+				err: sentinel,
+			},
+			want: true,
+		},
+		{
+			name: "IsKind",
+			fields: fields{
+				err:       sentinel,
+				separator: _separator(),
+			},
+			args: args{
+				err: New(sentinel),
+			},
+			want: true,
+		},
+		{
+			name: "IsNotSameString",
+			fields: fields{
+				err:       errors.New("test"),
+				separator: _separator(),
+			},
+			args: args{
+				err: sentinel,
+			},
+			want: false,
+		},
+		{
+			name: "IsString",
 			fields: fields{
 				err:       errors.New("test"),
 				separator: _separator(),
+				match:     matchString,
 			},
 			args: args{
 				err: errors.New("test"),
@@ -157,6 +193,7 @@ func Test_kind_Is(t *testing.T) {
 			k := kind{
 				err:       tt.fields.err,
 				separator: tt.fields.separator,
+				match:     tt.fields.match,
 			}
 			if got := k.Is(tt.args.err); got != tt.want {
 				t.Errorf("Is() = %v, want %v", got, tt.want)
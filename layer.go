@@ -0,0 +1,210 @@
+package gerr
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// layer is a single entry of the error chain held by the package type Wrapped, it keeps the original error value that
+// was added to the chain, so that it can be matched by identity and type instead of by its string representation
+type layer struct {
+	// msg is the message of this layer alone, when empty the message of orig is used
+	msg string
+	// approximate is set when msg was guessed from a foreign error, see ownMessage
+	approximate bool
+	// orig is the original error value of this layer
+	orig error
+	// shallow is set when orig is a foreign wrapper whose wrapped errors are already part of the chain, in which case
+	// only orig itself takes part in matching
+	shallow   bool
+	separator string
+	next      error
+	// stack is the stack trace captured when the layer was added, if any
+	stack *stack
+	// visibility is the audience the layer can be exposed to, see SanitizeTo
+	visibility Visibility
+}
+
+// Error implements the error interface
+func (l layer) Error() string {
+	if l.next == nil {
+		return l.text()
+	}
+	return l.text() + l.separator + l.next.Error()
+}
+
+// Unwrap implements the Unwrap interface
+func (l layer) Unwrap() error {
+	return l.next
+}
+
+// Is reports whether the original error of this layer matches the target, the remainder of the chain is walked by
+// errors.Is through Unwrap
+func (l layer) Is(target error) bool {
+	if l.orig == nil {
+		return false
+	}
+	if l.shallow {
+		return isSelf(l.orig, target)
+	}
+	return errors.Is(l.orig, target)
+}
+
+// As finds the first error in the original error of this layer that matches target, the remainder of the chain is
+// walked by errors.As through Unwrap
+func (l layer) As(target any) bool {
+	if l.orig == nil {
+		return false
+	}
+	if l.shallow {
+		return asSelf(l.orig, target)
+	}
+	return errors.As(l.orig, target)
+}
+
+// text returns the message of this layer alone
+func (l layer) text() string {
+	if l.msg == "" && l.orig != nil {
+		return l.orig.Error()
+	}
+	return l.msg
+}
+
+// layer returns this layer as an entry of the structural chain, the message of a layer split from a foreign wrapper is
+// trimmed the same way Layers trims the messages of foreign errors
+func (l layer) layer() Layer {
+	msg := l.text()
+	if l.shallow {
+		msg = strings.Trim(msg, wrappingCutset)
+	}
+	return Layer{Message: msg, Original: l.orig, Exact: !l.approximate}
+}
+
+// value returns the error that represents this layer in a chain, the original error is returned when it represents
+// the layer on its own, otherwise a new error is created from the message of the layer
+func (l layer) value() error {
+	if !l.shallow && l.orig != nil {
+		return l.orig
+	}
+	return errors.New(l.text())
+}
+
+// splitWrappedError splits the given error into layers by unwrapping it, the top most layer comes first, the string of
+// each layer is built by removing the string of the next error from the current one, wrappers that do not add a
+// message of their own are skipped
+func splitWrappedError(err error, sep string) []layer {
+	conf := sanitizeConfig{}
+	WithTrimCustom(sep)(&conf)
+	var layers []layer
+	for current := err; current != nil; {
+		next := errors.Unwrap(current)
+		s, exact := ownMessage(current, next)
+		if s != "" {
+			layers = append(layers, layer{
+				msg:         sanitizeString(s, conf),
+				approximate: !exact,
+				orig:        current,
+				shallow:     next != nil,
+				separator:   sep,
+			})
+		}
+		current = next
+	}
+	return layers
+}
+
+// splitMultiWrapper returns the layer holding the own message of the given error that wraps the given errors, as
+// fmt.Errorf does with multiple %w verbs, the spaces left by the removed messages are collapsed, the layer only matches
+// the error itself, as its wrapped errors are branches
+func splitMultiWrapper(err error, errs []error, sep string) layer {
+	conf := sanitizeConfig{}
+	WithTrimCustom(sep)(&conf)
+	s, _ := ownMessages(err, errs)
+	return layer{
+		msg:         sanitizeString(strings.Join(strings.Fields(s), " "), conf),
+		approximate: true,
+		orig:        err,
+		shallow:     true,
+		separator:   sep,
+	}
+}
+
+// appendChain returns the given chain with the next chain linked below its bottom most entry, the entries that are not
+// layers are converted to layers joined by the given separator
+func appendChain(err, next error, sep string) error {
+	if err == nil {
+		return next
+	}
+	l, ok := err.(layer)
+	if !ok {
+		l = layer{orig: err, separator: sep}
+	}
+	l.next = appendChain(l.next, next, sep)
+	return l
+}
+
+// linkLayers links the given layers, top most first, into a single error chain
+func linkLayers(layers []layer) error {
+	var err error
+	for i := len(layers) - 1; i >= 0; i-- {
+		l := layers[i]
+		l.next = err
+		err = l
+	}
+	return err
+}
+
+// relinkLayers returns a copy of the given chain of layers using the given separator, the chain is copied up to the
+// first error that is not a layer
+func relinkLayers(err error, sep string) error {
+	l, ok := err.(layer)
+	if !ok {
+		return err
+	}
+	l.separator = sep
+	l.next = relinkLayers(l.next, sep)
+	return l
+}
+
+// ownValue returns the error that a package type contributes to a chain on its own, this allows Chain to return the
+// original error values instead of errors re-created from their strings
+func ownValue(err error) (error, bool) {
+	switch e := err.(type) {
+	case kind:
+		return e.value(), true
+	case wrapped:
+		return e.kind.value(), true
+	case layer:
+		return e.value(), true
+	}
+	return nil, false
+}
+
+// isSelf reports whether err matches target without unwrapping err
+func isSelf(err, target error) bool {
+	if reflect.TypeOf(target).Comparable() && err == target {
+		return true
+	}
+	if x, ok := err.(interface{ Is(error) bool }); ok {
+		return x.Is(target)
+	}
+	return false
+}
+
+// asSelf reports whether err matches target without unwrapping err, setting target to err when it does
+func asSelf(err error, target any) bool {
+	val := reflect.ValueOf(target)
+	if target == nil || val.Kind() != reflect.Ptr || val.IsNil() {
+		return false
+	}
+	targetType := val.Type().Elem()
+	if reflect.TypeOf(err).AssignableTo(targetType) {
+		val.Elem().Set(reflect.ValueOf(err))
+		return true
+	}
+	if x, ok := err.(interface{ As(any) bool }); ok {
+		return x.As(target)
+	}
+	return false
+}
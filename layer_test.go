@@ -0,0 +1,98 @@
+package gerr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func Test_splitWrappedError(t *testing.T) {
+	bottom := errors.New("bot3")
+	mid := fmt.Errorf("mid2%s%w", _separator(), bottom)
+	top := fmt.Errorf("top1%s%w", _separator(), mid)
+	tests := []struct {
+		name string
+		err  error
+		want []layer
+	}{
+		{
+			name: "Split",
+			err:  top,
+			want: []layer{
+				{msg: "top1", orig: top, shallow: true, separator: _separator()},
+				{msg: "mid2", orig: mid, shallow: true, separator: _separator()},
+				{msg: "bot3", orig: bottom, separator: _separator()},
+			},
+		},
+		{
+			name: "SplitSkipsEmpty",
+			err:  fmt.Errorf("%w", bottom),
+			want: []layer{
+				{msg: "bot3", orig: bottom, separator: _separator()},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitWrappedError(tt.err, _separator()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitWrappedError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_layer_Is(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	tests := []struct {
+		name   string
+		l      layer
+		target error
+		want   bool
+	}{
+		{
+			name:   "IsOriginal",
+			l:      layer{orig: sentinel},
+			target: sentinel,
+			want:   true,
+		},
+		{
+			name:   "IsWrappedInOriginal",
+			l:      layer{orig: fmt.Errorf("context: %w", sentinel)},
+			target: sentinel,
+			want:   true,
+		},
+		{
+			name:   "IsNotWrappedInShallowOriginal",
+			l:      layer{orig: fmt.Errorf("context: %w", sentinel), shallow: true},
+			target: sentinel,
+			want:   false,
+		},
+		{
+			name:   "IsNotSameString",
+			l:      layer{orig: errors.New("sentinel")},
+			target: sentinel,
+			want:   false,
+		},
+		{
+			name:   "IsNext",
+			l:      layer{orig: errors.New("other"), next: sentinel},
+			target: sentinel,
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.l.Is(tt.target); got != tt.want {
+				t.Errorf("Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_layer_Error(t *testing.T) {
+	l := layer{orig: errors.New("added"), separator: _separator(), next: layer{msg: "mid", separator: _separator()}}
+	if got, want := l.Error(), "added"+_separator()+"mid"; got != want {
+		t.Errorf("Error() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,253 @@
+// Package metrics counts gerr errors by kind, chain depth and classification, and exposes the counts through expvar and
+// the Prometheus text exposition format without depending on a metrics library.
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/insan1k/gerr"
+)
+
+const (
+	// EventCreated is the event of the errors counted when they are created, see Metrics.Instrument
+	EventCreated = "created"
+	// EventReturned is the event of the errors counted by Metrics.Observe
+	EventReturned = "returned"
+	// OtherKind is the kind label of the errors whose kind is not counted on its own once the maximum amount of kinds
+	// is reached
+	OtherKind = "other"
+	// UnregisteredKind is the kind label of the errors whose kind is not registered when only the codes are counted,
+	// see WithCodesOnly
+	UnregisteredKind = "unregistered"
+)
+
+// Option is the functional type for configuring Metrics
+type Option func(m *Metrics)
+
+// WithNamespace prefixes the name of the metric exposed to Prometheus, gerr_errors_total becomes
+// <namespace>_gerr_errors_total
+func WithNamespace(namespace string) Option {
+	return func(m *Metrics) {
+		m.name = namespace + "_" + m.name
+	}
+}
+
+// WithMaxKinds sets the maximum amount of distinct kind labels, the errors of the kinds seen afterwards are counted
+// under OtherKind so that templated messages cannot explode the amount of series, 100 by default
+func WithMaxKinds(n int) Option {
+	return func(m *Metrics) {
+		m.maxKinds = n
+	}
+}
+
+// WithMaxDepth sets the depth above which the chains are counted together, under the depth label "<n>+", 5 by default
+func WithMaxDepth(n int) Option {
+	return func(m *Metrics) {
+		m.maxDepth = n
+	}
+}
+
+// WithCodesOnly labels the errors with the code of their kind only, the errors whose kind is not registered are
+// counted under UnregisteredKind, by default the message of the kind is used when it is not registered
+func WithCodesOnly() Option {
+	return func(m *Metrics) {
+		m.codesOnly = true
+	}
+}
+
+// Sample is the count of the errors of an event sharing the same labels
+type Sample struct {
+	Event string `json:"event"`
+	Kind  string `json:"kind"`
+	Depth string `json:"depth"`
+	Class string `json:"class"`
+	Count uint64 `json:"count"`
+}
+
+// series are the labels of a Sample
+type series struct {
+	event, kind, depth, class string
+}
+
+// Metrics counts errors by kind, chain depth and classification, it is safe for concurrent use
+type Metrics struct {
+	name      string
+	maxKinds  int
+	maxDepth  int
+	codesOnly bool
+
+	mu     sync.Mutex
+	counts map[series]uint64
+	kinds  map[string]bool
+}
+
+// New returns Metrics configured with the given options
+func New(opts ...Option) *Metrics {
+	m := &Metrics{
+		name:     "gerr_errors_total",
+		maxKinds: 100,
+		maxDepth: 5,
+		counts:   map[series]uint64{},
+		kinds:    map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Instrument counts every Grr created from now on under EventCreated, see gerr.OnNew, it returns a function that stops
+// counting them
+func (m *Metrics) Instrument() (remove func()) {
+	return gerr.OnNew(func(g gerr.Grr, _ runtime.Frame) {
+		m.count(EventCreated, g)
+	})
+}
+
+// Observe counts the error under EventReturned, it is meant to be called where errors leave the application, such as
+// in the handlers of a server, every branch of a joined error is counted, nil errors are not counted
+func (m *Metrics) Observe(err error) {
+	if err == nil {
+		return
+	}
+	m.count(EventReturned, gerr.AsGrr(err))
+}
+
+// count counts the Grr, or each of its branches, under the given event
+func (m *Metrics) count(event string, g gerr.Grr) {
+	if u, ok := g.(interface{ Unwrap() []error }); ok {
+		for _, branch := range u.Unwrap() {
+			m.count(event, gerr.AsGrr(branch))
+		}
+		return
+	}
+	kind := m.kindLabel(g)
+	s := series{event: event, depth: m.depthLabel(g), class: classLabel(g)}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.kinds[kind] {
+		if len(m.kinds) >= m.maxKinds {
+			kind = OtherKind
+		} else {
+			m.kinds[kind] = true
+		}
+	}
+	s.kind = kind
+	m.counts[s]++
+}
+
+// kindLabel returns the kind label of the Grr, see gerr.KindLabel, the kinds that are not registered share a label
+// when only the codes are counted
+func (m *Metrics) kindLabel(g gerr.Grr) string {
+	if m.codesOnly && g.Code() == "" {
+		return UnregisteredKind
+	}
+	return gerr.KindLabel(g)
+}
+
+// depthLabel returns the length of the chain of the Grr, the chains longer than the maximum depth share a label
+func (m *Metrics) depthLabel(g gerr.Grr) string {
+	depth := len(g.Chain())
+	if depth >= m.maxDepth {
+		return strconv.Itoa(m.maxDepth) + "+"
+	}
+	return strconv.Itoa(depth)
+}
+
+// classLabel returns the classification of the error, the first of permanent, timeout, temporary and retryable it has,
+// see gerr.TraitsOf, or none
+func classLabel(err error) string {
+	traits := gerr.TraitsOf(err)
+	switch {
+	case traits&gerr.Permanent != 0:
+		return "permanent"
+	case traits&gerr.Timeout != 0:
+		return "timeout"
+	case traits&gerr.Temporary != 0:
+		return "temporary"
+	case traits&gerr.Retryable != 0:
+		return "retryable"
+	}
+	return "none"
+}
+
+// Snapshot returns the counts, sorted by event, kind, depth and class
+func (m *Metrics) Snapshot() []Sample {
+	m.mu.Lock()
+	samples := make([]Sample, 0, len(m.counts))
+	for s, n := range m.counts {
+		samples = append(samples, Sample{Event: s.event, Kind: s.kind, Depth: s.depth, Class: s.class, Count: n})
+	}
+	m.mu.Unlock()
+	sort.Slice(samples, func(i, j int) bool {
+		a, b := samples[i], samples[j]
+		if a.Event != b.Event {
+			return a.Event < b.Event
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Depth != b.Depth {
+			return a.Depth < b.Depth
+		}
+		return a.Class < b.Class
+	})
+	return samples
+}
+
+// Reset removes every count and every kind label seen
+func (m *Metrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counts = map[series]uint64{}
+	m.kinds = map[string]bool{}
+}
+
+// String implements the expvar.Var interface, the counts are rendered as a JSON array of samples, so that Metrics can
+// be published with expvar.Publish
+func (m *Metrics) String() string {
+	data, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		return "[]"
+	}
+	return string(data)
+}
+
+// ContentType is the media type of the Prometheus text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Handler returns an http.Handler writing the counts in the Prometheus text exposition format
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		_, _ = w.Write([]byte(m.Prometheus()))
+	})
+}
+
+// Prometheus returns the counts in the Prometheus text exposition format, as a single counter with the event, kind,
+// depth and class labels
+func (m *Metrics) Prometheus() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# HELP %s Errors by event, kind, chain depth and class.\n", m.name)
+	fmt.Fprintf(&b, "# TYPE %s counter\n", m.name)
+	for _, s := range m.Snapshot() {
+		fmt.Fprintf(&b, "%s{event=\"%s\",kind=\"%s\",depth=\"%s\",class=\"%s\"} %d\n", m.name,
+			escapeLabel(s.Event), escapeLabel(s.Kind), escapeLabel(s.Depth), escapeLabel(s.Class), s.Count)
+	}
+	return b.String()
+}
+
+// labelEscaper escapes the characters that cannot appear as is in a label value of the text exposition format
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabel escapes the given label value for the text exposition format
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
@@ -0,0 +1,186 @@
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/insan1k/gerr"
+)
+
+var (
+	errNotFound = gerr.DefineKind("metrics.not_found", errors.New("not found"))
+	errConflict = errors.New("conflict")
+)
+
+func TestMetrics_Observe(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		errs []error
+		want []Sample
+	}{
+		{
+			name: "Kinds",
+			errs: []error{
+				gerr.New(errNotFound),
+				gerr.New(errNotFound).Add(errors.New("user 1")),
+				gerr.New(errNotFound).Add(errors.New("user 2")),
+				errConflict,
+				nil,
+			},
+			want: []Sample{
+				{Event: EventReturned, Kind: "conflict", Depth: "1", Class: "none", Count: 1},
+				{Event: EventReturned, Kind: "metrics.not_found", Depth: "1", Class: "none", Count: 1},
+				{Event: EventReturned, Kind: "metrics.not_found", Depth: "2", Class: "none", Count: 2},
+			},
+		},
+		{
+			name: "Classes",
+			errs: []error{
+				gerr.New(errConflict, gerr.WithTraits(gerr.Permanent, gerr.Retryable)),
+				gerr.New(errConflict, gerr.WithTraits(gerr.Retryable)),
+				gerr.Classify(errConflict, gerr.Temporary),
+				gerr.New(errConflict, gerr.WithTraits(gerr.Timeout)),
+			},
+			want: []Sample{
+				{Event: EventReturned, Kind: "conflict", Depth: "1", Class: "permanent", Count: 1},
+				{Event: EventReturned, Kind: "conflict", Depth: "1", Class: "retryable", Count: 1},
+				{Event: EventReturned, Kind: "conflict", Depth: "1", Class: "temporary", Count: 1},
+				{Event: EventReturned, Kind: "conflict", Depth: "1", Class: "timeout", Count: 1},
+			},
+		},
+		{
+			name: "MaxDepth",
+			opts: []Option{WithMaxDepth(2)},
+			errs: []error{
+				gerr.New(errConflict).Add(errors.New("two")),
+				gerr.New(errConflict).Add(errors.New("two")).Add(errors.New("three")),
+			},
+			want: []Sample{
+				{Event: EventReturned, Kind: "conflict", Depth: "2+", Class: "none", Count: 2},
+			},
+		},
+		{
+			name: "MaxKinds",
+			opts: []Option{WithMaxKinds(2)},
+			errs: []error{
+				gerr.Errorf("cannot read group %d", 1),
+				gerr.Errorf("cannot read group %d", 2),
+				gerr.Errorf("cannot read group %d", 3),
+				gerr.Errorf("cannot read group %d", 1),
+				gerr.Errorf("cannot read group %d", 4),
+			},
+			want: []Sample{
+				{Event: EventReturned, Kind: "cannot read group 1", Depth: "1", Class: "none", Count: 2},
+				{Event: EventReturned, Kind: "cannot read group 2", Depth: "1", Class: "none", Count: 1},
+				{Event: EventReturned, Kind: OtherKind, Depth: "1", Class: "none", Count: 2},
+			},
+		},
+		{
+			name: "CodesOnly",
+			opts: []Option{WithCodesOnly()},
+			errs: []error{gerr.New(errNotFound), gerr.Errorf("cannot read group %d", 1)},
+			want: []Sample{
+				{Event: EventReturned, Kind: "metrics.not_found", Depth: "1", Class: "none", Count: 1},
+				{Event: EventReturned, Kind: UnregisteredKind, Depth: "1", Class: "none", Count: 1},
+			},
+		},
+		{
+			name: "Redacted",
+			errs: []error{
+				gerr.New(errors.New("dial postgres://bob:hunter2@db:5432 failed"), gerr.WithRedaction(gerr.DefaultRedaction())),
+			},
+			want: []Sample{
+				{Event: EventReturned, Kind: "dial postgres://[REDACTED]@db:5432 failed", Depth: "1", Class: "none", Count: 1},
+			},
+		},
+		{
+			name: "Joined",
+			errs: []error{gerr.Join(errNotFound, errConflict)},
+			want: []Sample{
+				{Event: EventReturned, Kind: "conflict", Depth: "1", Class: "none", Count: 1},
+				{Event: EventReturned, Kind: "metrics.not_found", Depth: "1", Class: "none", Count: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(tt.opts...)
+			for _, err := range tt.errs {
+				m.Observe(err)
+			}
+			if got := m.Snapshot(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Snapshot() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetrics_Instrument(t *testing.T) {
+	m := New()
+	remove := m.Instrument()
+	gerr.New(errNotFound)
+	_ = gerr.New(errConflict).Add(errors.New("two"))
+	remove()
+	gerr.New(errNotFound)
+	want := []Sample{
+		{Event: EventCreated, Kind: "conflict", Depth: "1", Class: "none", Count: 1},
+		{Event: EventCreated, Kind: "metrics.not_found", Depth: "1", Class: "none", Count: 1},
+	}
+	if got := m.Snapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Snapshot() = %+v, want %+v", got, want)
+	}
+	m.Reset()
+	if got := m.Snapshot(); len(got) != 0 {
+		t.Errorf("Snapshot() after Reset() = %+v, want none", got)
+	}
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	m := New(WithNamespace("app"))
+	m.Observe(gerr.New(errNotFound))
+	m.Observe(fmt.Errorf("%w", errors.New("quote \" backslash \\ newline \n")))
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if got := rec.Header().Get("Content-Type"); got != ContentType {
+		t.Errorf("Content-Type = %q, want %q", got, ContentType)
+	}
+	want := "# HELP app_gerr_errors_total Errors by event, kind, chain depth and class.\n" +
+		"# TYPE app_gerr_errors_total counter\n" +
+		`app_gerr_errors_total{event="returned",kind="metrics.not_found",depth="1",class="none"} 1` + "\n" +
+		`app_gerr_errors_total{event="returned",kind="quote \" backslash \\ newline \n",depth="1",class="none"} 1` + "\n"
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+// expvarMetrics is published once, as expvar panics when a name is published twice, which happens when the tests run
+// more than once
+var (
+	expvarMetrics = New()
+	expvarPublish sync.Once
+)
+
+func TestMetrics_Expvar(t *testing.T) {
+	expvarPublish.Do(func() {
+		expvar.Publish("gerr_test_errors", expvarMetrics)
+	})
+	m := expvarMetrics
+	m.Reset()
+	m.Observe(gerr.New(errNotFound))
+	var got []Sample
+	if err := json.Unmarshal([]byte(expvar.Get("gerr_test_errors").String()), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	want := []Sample{{Event: EventReturned, Kind: "metrics.not_found", Depth: "1", Class: "none", Count: 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expvar = %+v, want %+v", got, want)
+	}
+}
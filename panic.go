@@ -0,0 +1,74 @@
+package gerr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPanic is the kind of the errors returned by Recover and Go when a panic is recovered
+var ErrPanic = errors.New("panic")
+
+// PanicError holds the value a panic was called with, it is the entry below ErrPanic in the chain of the errors
+// returned by Recover and Go, when the value is an error, such as a runtime.Error, it is unwrapped so that it can be
+// matched with errors.Is and errors.As
+type PanicError struct {
+	Value any
+}
+
+// Error implements the error interface
+func (p PanicError) Error() string {
+	return fmt.Sprint(p.Value)
+}
+
+// Unwrap returns the value of the panic when it is an error
+func (p PanicError) Unwrap() error {
+	err, _ := p.Value.(error)
+	return err
+}
+
+// Recover recovers from a panic and stores it in the given error as a Grr of the kind ErrPanic, replacing the error
+// that was there, the value of the panic is kept in a PanicError and the stack trace of the panic site is captured,
+// the error is left untouched when there is no panic. It must be deferred directly for the panic to be recovered.
+//
+//	func run() (err error) {
+//		defer gerr.Recover(&err)
+//		...
+//	}
+func Recover(err *error) {
+	if v := recover(); v != nil {
+		*err = newPanic(v, panicCallers())
+	}
+}
+
+// Go calls the given function in a new goroutine, the returned channel receives the error it returns, or the error of
+// the kind ErrPanic it panicked with, see Recover, and is closed afterwards
+func Go(fn func() error) <-chan error {
+	ch := make(chan error, 1)
+	go func() {
+		var err error
+		defer func() {
+			ch <- err
+			close(ch)
+		}()
+		defer Recover(&err)
+		err = fn()
+	}()
+	return ch
+}
+
+// newPanic returns the Grr of the kind ErrPanic holding the given panic value and stack trace, the type of the value
+// is attached as the panic_type field
+func newPanic(v any, st *stack) Grr {
+	w := newWrapped(DefaultConfig(), ErrPanic, chainErr(PanicError{Value: v}), WithField("panic_type", fmt.Sprintf("%T", v)))
+	w.kind.stack = st
+	return fire(hookNew, w)
+}
+
+// panicCallers captures the stack trace of a panicking goroutine from one of its deferred functions, the frames of the
+// runtime that handle the panic are removed along with the ones of this package, so that the first frame is the one
+// that panicked
+func panicCallers() *stack {
+	s := callers()
+	s.panicked = true
+	return s
+}
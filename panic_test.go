@@ -0,0 +1,114 @@
+package gerr
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+var errPanicValue = errors.New("panic value")
+
+func panicWith(v any) (err error) {
+	defer Recover(&err)
+	panic(v)
+}
+
+func panicIndex(i int) (err error) {
+	defer Recover(&err)
+	s := []int{1}
+	_ = s[i]
+	return errors.New("unreachable")
+}
+
+func TestRecover(t *testing.T) {
+	tests := []struct {
+		name      string
+		run       func() error
+		wantError string
+		wantIs    error
+		wantType  string
+	}{
+		{
+			name:      "String",
+			run:       func() error { return panicWith("boom") },
+			wantError: "panic boom",
+			wantType:  "string",
+		},
+		{
+			name:      "Error",
+			run:       func() error { return panicWith(errPanicValue) },
+			wantError: "panic panic value",
+			wantIs:    errPanicValue,
+			wantType:  "*errors.errorString",
+		},
+		{
+			name:      "RuntimeError",
+			run:       func() error { return panicIndex(3) },
+			wantError: "panic runtime error: index out of range [3] with length 1",
+			wantType:  "runtime.boundsError",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run()
+			g := AsGrr(err)
+			if g == nil || !g.Is(ErrPanic) {
+				t.Fatalf("Recover() error = %v, want an error of the kind ErrPanic", err)
+			}
+			if err.Error() != tt.wantError {
+				t.Errorf("Recover() error = %q, want %q", err.Error(), tt.wantError)
+			}
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Errorf("errors.Is(%v) = false, want true", tt.wantIs)
+			}
+			var p PanicError
+			if !errors.As(err, &p) {
+				t.Errorf("errors.As(PanicError) = false, want true")
+			}
+			if fields := g.Fields(); len(fields) != 1 || fields[0].Value != tt.wantType {
+				t.Errorf("Fields() = %v, want panic_type=%s", fields, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestRecoverRuntimeError(t *testing.T) {
+	err := panicIndex(3)
+	var re runtime.Error
+	if !errors.As(err, &re) {
+		t.Fatalf("errors.As(runtime.Error) = false, want true")
+	}
+	frames := AsGrr(err).StackTrace()
+	if len(frames) == 0 || !strings.HasSuffix(frames[0].Function, ".panicIndex") {
+		t.Errorf("StackTrace()[0] = %v, want the frame of panicIndex", frames)
+	}
+}
+
+func TestRecoverNoPanic(t *testing.T) {
+	want := errors.New("returned")
+	got := func() (err error) {
+		defer Recover(&err)
+		return want
+	}()
+	if got != want {
+		t.Errorf("Recover() error = %v, want %v", got, want)
+	}
+}
+
+func TestGo(t *testing.T) {
+	want := errors.New("returned")
+	if err := <-Go(func() error { return want }); err != want {
+		t.Errorf("Go() error = %v, want %v", err, want)
+	}
+	if err := <-Go(func() error { return nil }); err != nil {
+		t.Errorf("Go() error = %v, want nil", err)
+	}
+	err := <-Go(func() error { panic(errPanicValue) })
+	if !AsGrr(err).Is(ErrPanic) || !errors.Is(err, errPanicValue) {
+		t.Errorf("Go() error = %v, want an error of the kind ErrPanic wrapping %v", err, errPanicValue)
+	}
+	if frames := AsGrr(err).StackTrace(); len(frames) == 0 || !strings.Contains(frames[0].Function, "TestGo") {
+		t.Errorf("StackTrace()[0] = %v, want the frame of the panicking function", frames)
+	}
+}
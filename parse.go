@@ -0,0 +1,218 @@
+package gerr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrAmbiguousMessage is the kind of the error returned by Parse when more than one kind matches the same part of
+	// the message and the ambiguity is rejected, see RejectAmbiguous
+	ErrAmbiguousMessage = errors.New("gerr: ambiguous message")
+	// ErrUnknownKind is the kind of the error returned by Parse when the message does not start with a known kind and
+	// a known kind is required, see WithKnownKind
+	ErrUnknownKind = errors.New("gerr: unknown kind")
+)
+
+// Ambiguity decides what Parse does when more than one kind matches the same part of the message, as happens when the
+// message of a kind starts with the message of another kind followed by the separator
+type Ambiguity int
+
+const (
+	// PreferLongest picks the kind with the longest message, it is the default
+	PreferLongest Ambiguity = iota
+	// PreferShortest picks the kind with the shortest message
+	PreferShortest
+	// RejectAmbiguous makes Parse return an error of the kind ErrAmbiguousMessage
+	RejectAmbiguous
+)
+
+// ParseOpt is the functional type for configuring Parse
+type ParseOpt func(c *parseConfig)
+
+// parseConfig is the configuration of Parse
+type parseConfig struct {
+	separator    *string
+	ambiguity    Ambiguity
+	splitUnknown bool
+	knownKind    bool
+	kinds        []error
+}
+
+// WithParseSeparator sets the separator the message is split with, the separator of the Config by default
+func WithParseSeparator(sep string) ParseOpt {
+	return func(c *parseConfig) {
+		c.separator = &sep
+	}
+}
+
+// WithAmbiguity sets what Parse does when more than one kind matches the same part of the message, PreferLongest by
+// default
+func WithAmbiguity(a Ambiguity) ParseOpt {
+	return func(c *parseConfig) {
+		c.ambiguity = a
+	}
+}
+
+// WithSplitUnknown splits the parts of the message that match no kind on every separator, by default each of them is
+// kept as a single entry of the chain, since the separator may as well be part of the messages, as it is for the
+// default space separator
+func WithSplitUnknown() ParseOpt {
+	return func(c *parseConfig) {
+		c.splitUnknown = true
+	}
+}
+
+// WithKnownKind makes Parse return an error of the kind ErrUnknownKind when the message does not start with a known
+// kind, by default the leading part of the message becomes the kind of the Grr
+func WithKnownKind() ParseOpt {
+	return func(c *parseConfig) {
+		c.knownKind = true
+	}
+}
+
+// WithParseKinds adds the given kinds to the ones recognized by Parse, on top of the kinds registered with Register or
+// RegisterKinds
+func WithParseKinds(kinds ...error) ParseOpt {
+	return func(c *parseConfig) {
+		c.kinds = append(c.kinds, kinds...)
+	}
+}
+
+// Parse reconstructs a Grr from a flat message, such as the string returned by Error that reached a service without
+// its Unwrap chain, see the Parse function of Config
+func Parse(s string, opts ...ParseOpt) (Grr, error) {
+	return DefaultConfig().Parse(s, opts...)
+}
+
+// Parse reconstructs a Grr from a flat message using the defaults of the Config, the message is split on the separator
+// around the messages of the known kinds, which are the kinds registered with Register or RegisterKinds and the ones
+// given with WithParseKinds, the known kinds are kept as is so that the result matches them with Is, while the parts
+// of the message in between become new errors. The top most part of the message becomes the kind of the Grr, and the
+// remaining parts its chain, top most first, so that the Grr has the same message. A nil Grr is returned for an empty
+// message.
+func (c Config) Parse(s string, opts ...ParseOpt) (Grr, error) {
+	conf := parseConfig{}
+	for _, opt := range opts {
+		opt(&conf)
+	}
+	if conf.separator != nil {
+		c.Separator = *conf.separator
+	}
+	if s == "" {
+		return nil, nil
+	}
+	parts, err := parseParts(s, c.Separator, knownKinds(conf.kinds), conf)
+	if err != nil {
+		return nil, err
+	}
+	if conf.knownKind && !parts[0].known {
+		return nil, New(ErrUnknownKind).Add(fmt.Errorf("message %q does not start with a known kind", s))
+	}
+	var chainOpts []Option
+	for i := len(parts) - 1; i > 0; i-- {
+		chainOpts = append(chainOpts, chainErr(parts[i].err))
+	}
+	return c.New(parts[0].err, chainOpts...), nil
+}
+
+// parsedPart is a part of a parsed message, known parts hold the kind whose message they matched
+type parsedPart struct {
+	err   error
+	known bool
+}
+
+// knownKinds returns the registered kinds and the given ones keyed by their message
+func knownKinds(extra []error) map[string]error {
+	kinds := map[string]error{}
+	_kinds.Range(func(msg, k any) bool {
+		kinds[msg.(string)] = k.(error)
+		return true
+	})
+	for _, k := range extra {
+		kinds[k.Error()] = k
+	}
+	return kinds
+}
+
+// parseParts splits the message into parts, at each separator boundary the messages of the known kinds are matched,
+// the text between the matches becomes the unknown parts
+func parseParts(s, sep string, kinds map[string]error, conf parseConfig) ([]parsedPart, error) {
+	var parts []parsedPart
+	unknown := func(text string) {
+		if text == "" {
+			return
+		}
+		if !conf.splitUnknown || sep == "" {
+			parts = append(parts, parsedPart{err: errors.New(text)})
+			return
+		}
+		for _, t := range strings.Split(text, sep) {
+			if t != "" {
+				parts = append(parts, parsedPart{err: errors.New(t)})
+			}
+		}
+	}
+	start := 0
+	for pos := 0; pos < len(s); {
+		matches := kindsAt(s, pos, sep, kinds)
+		if len(matches) > 1 && conf.ambiguity == RejectAmbiguous {
+			return nil, New(ErrAmbiguousMessage).Add(fmt.Errorf("kinds %q all match %q", matches, s[pos:]))
+		}
+		if len(matches) > 0 {
+			m := matches[0]
+			if conf.ambiguity == PreferShortest {
+				m = matches[len(matches)-1]
+			}
+			unknown(strings.TrimSuffix(s[start:pos], sep))
+			parts = append(parts, parsedPart{err: kinds[m], known: true})
+			pos += len(m) + len(sep)
+			start = pos
+			continue
+		}
+		pos += nextBoundary(s[pos:], sep)
+	}
+	if start < len(s) {
+		unknown(s[start:])
+	}
+	return parts, nil
+}
+
+// kindsAt returns the messages of the known kinds found at the given position of the message and followed by either
+// the separator or the end of the message, longest first
+func kindsAt(s string, pos int, sep string, kinds map[string]error) []string {
+	var matches []string
+	rest := s[pos:]
+	for msg := range kinds {
+		if msg == "" || !strings.HasPrefix(rest, msg) {
+			continue
+		}
+		if after := rest[len(msg):]; after == "" || strings.HasPrefix(after, sep) {
+			matches = append(matches, msg)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if len(matches[i]) != len(matches[j]) {
+			return len(matches[i]) > len(matches[j])
+		}
+		return matches[i] < matches[j]
+	})
+	return matches
+}
+
+// nextBoundary returns the offset of the position after the next separator of the message, or the length of the
+// message when there is none, every rune is a boundary when the separator is empty
+func nextBoundary(s, sep string) int {
+	if sep == "" {
+		_, n := utf8.DecodeRuneInString(s)
+		return n
+	}
+	i := strings.Index(s, sep)
+	if i < 0 {
+		return len(s)
+	}
+	return i + len(sep)
+}
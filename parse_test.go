@@ -0,0 +1,153 @@
+package gerr
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var (
+	errParseRead    = errors.New("failed to read")
+	errParseDB      = errors.New("fake DB error")
+	errParseFailed  = errors.New("failed")
+	errParseTimeout = errors.New("timeout")
+)
+
+func TestParse(t *testing.T) {
+	kinds := WithParseKinds(errParseRead, errParseDB, errParseTimeout)
+	tests := []struct {
+		name      string
+		msg       string
+		opts      []ParseOpt
+		wantChain []string
+		wantIs    []error
+		wantErr   error
+	}{
+		{
+			name:      "KnownKinds",
+			msg:       "failed to read fake DB error",
+			opts:      []ParseOpt{kinds},
+			wantChain: []string{"failed to read", "fake DB error"},
+			wantIs:    []error{errParseRead, errParseDB},
+		},
+		{
+			name:      "UnknownMiddle",
+			msg:       "failed to read cannot read group someGroup fake DB error",
+			opts:      []ParseOpt{kinds},
+			wantChain: []string{"failed to read", "cannot read group someGroup", "fake DB error"},
+			wantIs:    []error{errParseRead, errParseDB},
+		},
+		{
+			name:      "UnknownKind",
+			msg:       "reading config fake DB error",
+			opts:      []ParseOpt{kinds},
+			wantChain: []string{"reading config", "fake DB error"},
+			wantIs:    []error{errParseDB},
+		},
+		{
+			name:      "Separator",
+			msg:       "failed to read: dial tcp: timeout",
+			opts:      []ParseOpt{kinds, WithParseSeparator(": ")},
+			wantChain: []string{"failed to read", "dial tcp", "timeout"},
+			wantIs:    []error{errParseRead, errParseTimeout},
+		},
+		{
+			name:      "SplitUnknown",
+			msg:       "failed to read: dial tcp: lookup db: timeout",
+			opts:      []ParseOpt{kinds, WithParseSeparator(": "), WithSplitUnknown()},
+			wantChain: []string{"failed to read", "dial tcp", "lookup db", "timeout"},
+			wantIs:    []error{errParseRead, errParseTimeout},
+		},
+		{
+			name:      "NotABoundary",
+			msg:       "failed to reader timeout",
+			opts:      []ParseOpt{kinds},
+			wantChain: []string{"failed to reader", "timeout"},
+			wantIs:    []error{errParseTimeout},
+		},
+		{
+			name:      "PreferLongest",
+			msg:       "failed to read timeout",
+			opts:      []ParseOpt{kinds, WithParseKinds(errParseFailed)},
+			wantChain: []string{"failed to read", "timeout"},
+			wantIs:    []error{errParseRead, errParseTimeout},
+		},
+		{
+			name:      "PreferShortest",
+			msg:       "failed to read timeout",
+			opts:      []ParseOpt{kinds, WithParseKinds(errParseFailed), WithAmbiguity(PreferShortest)},
+			wantChain: []string{"failed", "to read", "timeout"},
+			wantIs:    []error{errParseFailed, errParseTimeout},
+		},
+		{
+			name:    "RejectAmbiguous",
+			msg:     "failed to read timeout",
+			opts:    []ParseOpt{kinds, WithParseKinds(errParseFailed), WithAmbiguity(RejectAmbiguous)},
+			wantErr: ErrAmbiguousMessage,
+		},
+		{
+			name:    "KnownKind",
+			msg:     "reading config fake DB error",
+			opts:    []ParseOpt{kinds, WithKnownKind()},
+			wantErr: ErrUnknownKind,
+		},
+		{
+			name: "Empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.msg, tt.opts...)
+			if tt.wantErr != nil {
+				if err == nil || !AsGrr(err).Is(tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if tt.msg == "" {
+				if got != nil {
+					t.Errorf("Parse() = %v, want nil", got)
+				}
+				return
+			}
+			if got.Error() != tt.msg {
+				t.Errorf("Parse().Error() = %q, want %q", got.Error(), tt.msg)
+			}
+			var chain []string
+			for _, e := range got.Chain() {
+				chain = append(chain, e.Error())
+			}
+			if !reflect.DeepEqual(chain, tt.wantChain) {
+				t.Errorf("Parse().Chain() = %q, want %q", chain, tt.wantChain)
+			}
+			for _, target := range tt.wantIs {
+				if !got.Is(target) {
+					t.Errorf("Parse().Is(%v) = false, want true", target)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	errRegistered := errors.New("parse round trip")
+	RegisterKinds(errRegistered)
+	c := Config{Separator: " -- "}
+	original := c.New(errRegistered).Add(errors.New("three")).Add(errors.New("two"))
+	got, err := c.Parse(original.Error(), WithSplitUnknown())
+	if err != nil {
+		t.Fatalf("Config.Parse() error = %v", err)
+	}
+	if got.Error() != original.Error() {
+		t.Errorf("Config.Parse().Error() = %q, want %q", got.Error(), original.Error())
+	}
+	if !got.Is(errRegistered) {
+		t.Errorf("Config.Parse().Is() = false, want true")
+	}
+	if !reflect.DeepEqual(got.Chain(), original.Chain()) {
+		t.Errorf("Config.Parse().Chain() = %q, want %q", got.Chain(), original.Chain())
+	}
+}
@@ -0,0 +1,139 @@
+package gerr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	// ErrDuplicateCode is the kind of the error returned by Register when the code or the kind are already registered
+	ErrDuplicateCode = errors.New("gerr: duplicate kind registration")
+	// ErrInvalidKind is the kind of the error returned by Register when the kind cannot be registered
+	ErrInvalidKind = errors.New("gerr: invalid kind registration")
+)
+
+// Code is the stable machine code of a registered kind, it can be given as the target of Is to match the kind
+// registered with it
+type Code string
+
+// Error implements the error interface
+func (c Code) Error() string {
+	return "gerr code " + string(c)
+}
+
+// _registry holds the kinds registered by Register keyed by their code and the codes keyed by their kind, while
+// _kinds holds the kinds registered by either Register or RegisterKinds keyed by their message
+var (
+	_registry = struct {
+		sync.RWMutex
+		byCode map[Code]error
+		byKind map[error]Code
+	}{
+		byCode: map[Code]error{},
+		byKind: map[error]Code{},
+	}
+	_kinds sync.Map
+)
+
+// Register registers the given kind with the given stable code, the code is returned by the Code function of the Grr
+// created from the kind, can be given to Is to match the kind, and is used to round trip the kind through JSON. An
+// error of the kind ErrDuplicateCode is returned when either the code or the kind are already registered with another
+// kind or code, registering the same pair twice is not an error.
+func Register(code string, kind error) error {
+	if code == "" || kind == nil || !reflect.TypeOf(kind).Comparable() {
+		return New(ErrInvalidKind).Add(fmt.Errorf("code %q kind %v must be a non empty code and a comparable error", code, kind))
+	}
+	_registry.Lock()
+	defer _registry.Unlock()
+	c := Code(code)
+	k, codeOk := _registry.byCode[c]
+	existing, kindOk := _registry.byKind[kind]
+	if codeOk && kindOk && k == kind && existing == c {
+		return nil
+	}
+	if codeOk {
+		return New(ErrDuplicateCode).Add(fmt.Errorf("code %q is already registered with kind %v", code, k))
+	}
+	if kindOk {
+		return New(ErrDuplicateCode).Add(fmt.Errorf("kind %v is already registered with code %q", kind, existing))
+	}
+	_registry.byCode[c] = kind
+	_registry.byKind[kind] = c
+	RegisterKinds(kind)
+	return nil
+}
+
+// DefineKind registers the given kind with the given stable code and returns the kind, it panics when the kind cannot
+// be registered, so that duplicate registrations are detected when the package variables are initialized
+//
+//	var ErrFailedToRead = gerr.DefineKind("app.read", errors.New("failed to read"))
+func DefineKind[E error](code string, kind E) E {
+	if err := Register(code, kind); err != nil {
+		panic(err)
+	}
+	return kind
+}
+
+// Lookup returns the kind registered with the given code
+func Lookup(code string) (error, bool) {
+	return lookupCode(code)
+}
+
+// KindLabel returns the code of the kind of the Grr, or the message of the kind when it is not registered, the message
+// is taken from the chain so that it is redacted as the Grr is, see RedactRule, it is meant to label the errors by kind
+// in logs and metrics
+func KindLabel(g Grr) string {
+	if code := g.Code(); code != "" {
+		return code
+	}
+	if chain := g.Chain(); len(chain) > 0 {
+		return chain[0].Error()
+	}
+	return g.Error()
+}
+
+// RegisterKinds registers the given errors so that the errors decoded by Decode that carry their messages are replaced
+// by them, this allows the decoded Grr to be matched by identity against the local errors
+func RegisterKinds(kinds ...error) {
+	for _, k := range kinds {
+		_kinds.Store(k.Error(), k)
+	}
+}
+
+// lookupKind returns the registered error with the given message, or a new error with the message if there is none
+func lookupKind(msg string) error {
+	if k, ok := _kinds.Load(msg); ok {
+		return k.(error)
+	}
+	return errors.New(msg)
+}
+
+// lookupCode returns the kind registered with the given code
+func lookupCode(code string) (error, bool) {
+	_registry.RLock()
+	defer _registry.RUnlock()
+	k, ok := _registry.byCode[Code(code)]
+	return k, ok
+}
+
+// codeOf returns the code the given kind is registered with, or an empty string if it is not registered
+func codeOf(kind error) string {
+	if kind == nil || !reflect.TypeOf(kind).Comparable() {
+		return ""
+	}
+	_registry.RLock()
+	defer _registry.RUnlock()
+	return string(_registry.byKind[kind])
+}
+
+// codeTarget replaces a Code target of Is with the kind registered with it, the second value reports whether the
+// target can be matched at all, which is not the case for a Code that is not registered
+func codeTarget(target error) (error, bool) {
+	c, ok := target.(Code)
+	if !ok {
+		return target, true
+	}
+	return lookupCode(string(c))
+}
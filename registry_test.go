@@ -0,0 +1,169 @@
+package gerr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+type registryTestKind int
+
+func (r registryTestKind) Error() string {
+	return "registry test kind"
+}
+
+var (
+	errRegistryRead  = DefineKind("registry.read", errors.New("registry failed to read"))
+	errRegistryWrite = DefineKind("registry.write", registryTestKind(1))
+	// registerRuns makes the codes registered by TestRegister unique across runs, as the registry is global
+	registerRuns atomic.Int64
+)
+
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		kind     error
+		wantKind error
+	}{
+		{
+			name: "Register",
+			code: fmt.Sprintf("registry.parse.%d", registerRuns.Add(1)),
+			kind: errors.New("registry failed to parse"),
+		},
+		{
+			name: "RegisterSamePair",
+			code: "registry.read",
+			kind: errRegistryRead,
+		},
+		{
+			name:     "DuplicateCode",
+			code:     "registry.read",
+			kind:     errors.New("registry failed to read"),
+			wantKind: ErrDuplicateCode,
+		},
+		{
+			name:     "DuplicateKind",
+			code:     "registry.read2",
+			kind:     errRegistryRead,
+			wantKind: ErrDuplicateCode,
+		},
+		{
+			name:     "EmptyCode",
+			code:     "",
+			kind:     errors.New("registry empty"),
+			wantKind: ErrInvalidKind,
+		},
+		{
+			name:     "NilKind",
+			code:     "registry.nil",
+			wantKind: ErrInvalidKind,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Register(tt.code, tt.kind)
+			if tt.wantKind == nil {
+				if err != nil {
+					t.Errorf("Register() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantKind) {
+				t.Errorf("Register() error = %v, want %v", err, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestDefineKindPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("DefineKind() did not panic on a duplicate code")
+		}
+	}()
+	DefineKind("registry.read", errors.New("another kind"))
+}
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Grr
+		want string
+	}{
+		{name: "Kind", got: New(errRegistryRead), want: "registry.read"},
+		{name: "Wrapped", got: New(errRegistryWrite).Add(errors.New("two")), want: "registry.write"},
+		{name: "Unregistered", got: New(errors.New("one")), want: ""},
+		{name: "Joined", got: Join(errors.New("one"), errRegistryRead), want: "registry.read"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.Code(); got != tt.want {
+				t.Errorf("Code() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKindLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Grr
+		want string
+	}{
+		{name: "Registered", got: New(errRegistryRead).Add(errors.New("two")), want: "registry.read"},
+		{name: "Unregistered", got: New(errors.New("one")).Add(errors.New("two")), want: "one"},
+		{
+			name: "Redacted",
+			got:  New(errors.New("dial postgres://bob:hunter2@db failed"), WithRedaction(RedactURLCredentials())),
+			want: "dial postgres://[REDACTED]@db failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KindLabel(tt.got); got != tt.want {
+				t.Errorf("KindLabel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCode(t *testing.T) {
+	err := New(errors.New("one")).Add(errRegistryWrite).Add(errors.New("three"))
+	if !err.Is(Code("registry.write")) || !errors.Is(err, Code("registry.write")) {
+		t.Errorf("Is() = false, want true for the code of an error in the chain")
+	}
+	if err.Is(Code("registry.read")) {
+		t.Errorf("Is() = true, want false for the code of an error not in the chain")
+	}
+	if New(errRegistryRead).Is(Code("registry.unknown")) {
+		t.Errorf("Is() = true, want false for an unregistered code")
+	}
+	if !New(errRegistryRead, WithStringMatching()).Is(Code("registry.read")) {
+		t.Errorf("Is() = false, want true for the code of the kind when matching by string")
+	}
+}
+
+func TestDecodeCode(t *testing.T) {
+	data, err := json.Marshal(New(errRegistryWrite).Add(errors.New("two")))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	// the message is changed to make sure the kind is resolved by its code
+	var j jsonGrr
+	_ = json.Unmarshal(data, &j)
+	if j.Code != "registry.write" {
+		t.Fatalf("json.Marshal() code = %v, want %v", j.Code, "registry.write")
+	}
+	j.Kind = "reworded message"
+	data, _ = json.Marshal(j)
+	got, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !got.Is(errRegistryWrite) || got.Code() != "registry.write" {
+		t.Errorf("Decode() = %v, want the kind registered with the code", got)
+	}
+}
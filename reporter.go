@@ -0,0 +1,328 @@
+package gerr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Report is an entry written by a Reporter to its sinks, either an error reported as is, or the summary of the
+// occurrences of an error that were suppressed during a window
+type Report struct {
+	// Err is the error reported, for a summary it is the first error of the window
+	Err error
+	// Fingerprint is the fingerprint of the error, see Fingerprint
+	Fingerprint string
+	// Kind is the label of the kind of the error, see KindLabel
+	Kind string
+	// Summary is set when the report summarizes the suppressed occurrences of the error
+	Summary bool
+	// Count is the amount of occurrences the report stands for, 1 for an error and the amount of suppressed
+	// occurrences for a summary
+	Count uint64
+	// Window is the duration the occurrences of the summary were suppressed for
+	Window time.Duration
+	// Time is the time the report was written
+	Time time.Time
+}
+
+// String returns the message of the error, followed for a summary by the amount of occurrences and the window
+func (r Report) String() string {
+	if !r.Summary {
+		return r.Err.Error()
+	}
+	return fmt.Sprintf("%v x%d in last %s", r.Err, r.Count, r.Window)
+}
+
+// Sink is the functional type that writes the reports of a Reporter, the sinks of a Reporter are called sequentially
+type Sink func(r Report)
+
+// WriterSink returns a sink that writes each report on its own line
+func WriterSink(w io.Writer) Sink {
+	return func(r Report) {
+		_, _ = fmt.Fprintln(w, r.String())
+	}
+}
+
+// SlogSink returns a sink that logs each report at the given level with the error, see the LogValue implementations
+// of the package types, and its fingerprint, summaries also have the count and the window attributes
+func SlogSink(logger *slog.Logger, level slog.Level) Sink {
+	return func(r Report) {
+		attrs := []slog.Attr{slog.Any("error", r.Err), slog.String("fingerprint", r.Fingerprint)}
+		if r.Summary {
+			attrs = append(attrs, slog.Uint64("count", r.Count), slog.Duration("window", r.Window))
+		}
+		logger.LogAttrs(context.Background(), level, r.String(), attrs...)
+	}
+}
+
+// ReporterOpt is the functional type for configuring a Reporter
+type ReporterOpt func(r *Reporter)
+
+// WithSink adds the given sink to the ones the reports are written to
+func WithSink(s Sink) ReporterOpt {
+	return func(r *Reporter) {
+		r.sinks = append(r.sinks, s)
+	}
+}
+
+// defaultReportWindow is the window of a Reporter unless another one is set by WithWindow
+const defaultReportWindow = 10 * time.Second
+
+// WithWindow sets the duration during which the occurrences of an error are suppressed once it was reported, 10s by
+// default, durations that are not positive are ignored
+func WithWindow(d time.Duration) ReporterOpt {
+	return func(r *Reporter) {
+		if d > 0 {
+			r.window = d
+		}
+	}
+}
+
+// WithRateLimit limits the errors of every kind to n reports every given interval, the errors over the limit are
+// suppressed and counted in the summaries, there is no limit by default
+func WithRateLimit(n int, every time.Duration) ReporterOpt {
+	return func(r *Reporter) {
+		r.limit = rateLimit{n: n, every: every}
+	}
+}
+
+// WithKindRateLimit limits the errors of the given kind to n reports every given interval, overriding the limit set by
+// WithRateLimit for that kind
+func WithKindRateLimit(kind error, n int, every time.Duration) ReporterOpt {
+	return func(r *Reporter) {
+		r.kindLimits[KindLabel(DefaultConfig().asGrr(kind))] = rateLimit{n: n, every: every}
+	}
+}
+
+// WithReporterNormalizers sets the normalizers the fingerprints are computed with, see Fingerprint
+func WithReporterNormalizers(normalizers ...Normalizer) ReporterOpt {
+	return func(r *Reporter) {
+		r.normalizers = normalizers
+	}
+}
+
+// WithReporterClock sets the clock the windows and the rate limits are measured with, so that they can be tested, the
+// time package by default
+func WithReporterClock(clock Clock) ReporterOpt {
+	return func(r *Reporter) {
+		r.clock = clock
+	}
+}
+
+// rateLimit allows n reports every interval, a zero n means no limit
+type rateLimit struct {
+	n     int
+	every time.Duration
+}
+
+// rateWindow counts the reports of a kind in the current interval of its rate limit
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// dedupWindow holds the occurrences of an error suppressed since it was last reported
+type dedupWindow struct {
+	start      time.Time
+	first      error
+	kind       string
+	suppressed uint64
+}
+
+// Reporter deduplicates and rate limits errors before writing them to its sinks, the first occurrence of an error is
+// reported and the following ones sharing its fingerprint are suppressed for a window, after which a summary of the
+// suppressed occurrences is reported, the windows that are over are removed by Report at most once every window, and
+// by Flush, so that the memory held stays bounded by the errors seen in the last windows, it is safe for concurrent
+// use
+type Reporter struct {
+	sinks       []Sink
+	window      time.Duration
+	limit       rateLimit
+	kindLimits  map[string]rateLimit
+	normalizers []Normalizer
+	clock       Clock
+
+	mu      sync.Mutex
+	windows map[string]*dedupWindow
+	rates   map[string]*rateWindow
+	// expired is the last time the windows that are over were removed, see expire
+	expired time.Time
+	// emitMu serializes the calls to the sinks, which are made without holding mu
+	emitMu sync.Mutex
+}
+
+// NewReporter returns a Reporter configured with the given options
+func NewReporter(opts ...ReporterOpt) *Reporter {
+	r := &Reporter{
+		window:     defaultReportWindow,
+		kindLimits: map[string]rateLimit{},
+		clock:      realClock{},
+		windows:    map[string]*dedupWindow{},
+		rates:      map[string]*rateWindow{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Report reports the error unless an error with the same fingerprint was reported during the window, or its kind is
+// over its rate limit, in which case it is counted in the summary written once the window is over, nil errors are not
+// reported
+func (r *Reporter) Report(err error) {
+	if err == nil {
+		return
+	}
+	fp := Fingerprint(err, r.normalizers...)
+	kind := KindLabel(DefaultConfig().asGrr(err))
+	now := r.clock.Now()
+	r.mu.Lock()
+	var reports []Report
+	if now.Sub(r.expired) >= r.window {
+		reports = r.expire(now, false)
+	}
+	w, ok := r.windows[fp]
+	if ok && now.Sub(w.start) >= r.window {
+		if s, ok := r.summary(fp, w, now); ok {
+			reports = append(reports, s)
+		}
+		ok = false
+	}
+	if !ok {
+		w = &dedupWindow{start: now, first: err, kind: kind}
+		r.windows[fp] = w
+		if r.allow(kind, now) {
+			reports = append(reports, Report{Err: err, Fingerprint: fp, Kind: kind, Count: 1, Time: now})
+		} else {
+			w.suppressed++
+		}
+	} else {
+		w.suppressed++
+	}
+	r.mu.Unlock()
+	r.emit(reports)
+}
+
+// Flush writes the summaries of the windows that are over, it is meant to be called periodically so that the
+// summaries are written even when the errors stop occurring, see Run
+func (r *Reporter) Flush() {
+	r.flush(false)
+}
+
+// Close writes the summaries of every window, including the ones that are not over, and forgets them
+func (r *Reporter) Close() {
+	r.flush(true)
+}
+
+// Run calls Flush every window, as measured by the clock of the Reporter, until the context is done, then calls Close
+func (r *Reporter) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			r.Close()
+			return
+		case <-r.clock.After(r.window):
+			r.Flush()
+		}
+	}
+}
+
+// flush writes the summaries of the windows that are over, or of every window when all is set, and forgets them
+func (r *Reporter) flush(all bool) {
+	now := r.clock.Now()
+	r.mu.Lock()
+	reports := r.expire(now, all)
+	r.mu.Unlock()
+	r.emit(reports)
+}
+
+// expire removes the windows that are over, or every window when all is set, and the rate windows that are over, it
+// returns the summaries of the removed windows sorted by fingerprint, mu must be held
+func (r *Reporter) expire(now time.Time, all bool) []Report {
+	r.expired = now
+	var reports []Report
+	for fp, w := range r.windows {
+		if !all && now.Sub(w.start) < r.window {
+			continue
+		}
+		if s, ok := r.summary(fp, w, now); ok {
+			reports = append(reports, s)
+		}
+		delete(r.windows, fp)
+	}
+	for kind, rw := range r.rates {
+		if now.Sub(rw.start) >= r.limitOf(kind).every {
+			delete(r.rates, kind)
+		}
+	}
+	sortReports(reports)
+	return reports
+}
+
+// summary returns the summary of the window, the second value reports whether any occurrence was suppressed
+func (r *Reporter) summary(fp string, w *dedupWindow, now time.Time) (Report, bool) {
+	if w.suppressed == 0 {
+		return Report{}, false
+	}
+	return Report{
+		Err:         w.first,
+		Fingerprint: fp,
+		Kind:        w.kind,
+		Summary:     true,
+		Count:       w.suppressed,
+		Window:      r.window,
+		Time:        now,
+	}, true
+}
+
+// allow reports whether an error of the given kind can be reported under its rate limit, counting it when it can
+func (r *Reporter) allow(kind string, now time.Time) bool {
+	limit := r.limitOf(kind)
+	if limit.n <= 0 {
+		return true
+	}
+	rw, ok := r.rates[kind]
+	if !ok || now.Sub(rw.start) >= limit.every {
+		rw = &rateWindow{start: now}
+		r.rates[kind] = rw
+	}
+	if rw.count >= limit.n {
+		return false
+	}
+	rw.count++
+	return true
+}
+
+// limitOf returns the rate limit of the given kind
+func (r *Reporter) limitOf(kind string) rateLimit {
+	if limit, ok := r.kindLimits[kind]; ok {
+		return limit
+	}
+	return r.limit
+}
+
+// emit writes the reports to every sink
+func (r *Reporter) emit(reports []Report) {
+	if len(reports) == 0 {
+		return
+	}
+	r.emitMu.Lock()
+	defer r.emitMu.Unlock()
+	for _, report := range reports {
+		for _, s := range r.sinks {
+			s(report)
+		}
+	}
+}
+
+// sortReports sorts the reports by their fingerprint, so that the summaries written together come in a stable order
+func sortReports(reports []Report) {
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Fingerprint < reports[j].Fingerprint
+	})
+}
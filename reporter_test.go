@@ -0,0 +1,238 @@
+package gerr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	errReportRead  = errors.New("failed to read")
+	errReportWrite = errors.New("failed to write")
+)
+
+// newTestReporter returns a Reporter writing to a buffer with a clock that only moves when it is advanced
+func newTestReporter(opts ...ReporterOpt) (*Reporter, *bytes.Buffer, *testClock) {
+	var buf bytes.Buffer
+	clock := newTestClock()
+	opts = append([]ReporterOpt{WithSink(WriterSink(&buf)), WithReporterClock(clock)}, opts...)
+	return NewReporter(opts...), &buf, clock
+}
+
+func TestReporter(t *testing.T) {
+	r, buf, clock := newTestReporter(WithWindow(10 * time.Second))
+	readGroup := func(group string) error {
+		return New(errReportRead).Add(fmt.Errorf("cannot read group %s", group))
+	}
+	r.Report(readGroup("someGroup"))
+	for i := 0; i < 1234; i++ {
+		clock.advance(time.Millisecond)
+		r.Report(readGroup("otherGroup"))
+	}
+	r.Report(nil)
+	r.Report(New(errReportWrite))
+	r.Flush()
+	clock.advance(10 * time.Second)
+	r.Report(readGroup("fooGroup"))
+	r.Flush()
+	clock.advance(10 * time.Second)
+	r.Report(New(errReportWrite))
+	r.Close()
+	want := strings.Join([]string{
+		"failed to read cannot read group someGroup",
+		"failed to write",
+		"failed to read cannot read group someGroup x1234 in last 10s",
+		"failed to read cannot read group fooGroup",
+		"failed to write",
+	}, "\n") + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("reports = %q, want %q", got, want)
+	}
+}
+
+func TestReporterFlush(t *testing.T) {
+	r, buf, clock := newTestReporter(WithWindow(time.Second))
+	r.Report(New(errReportRead))
+	r.Report(New(errReportRead))
+	r.Report(New(errReportWrite))
+	r.Report(New(errReportWrite))
+	r.Report(New(errReportWrite))
+	r.Flush()
+	if got, want := buf.String(), "failed to read\nfailed to write\n"; got != want {
+		t.Fatalf("reports before the end of the window = %q, want %q", got, want)
+	}
+	clock.advance(time.Second)
+	buf.Reset()
+	r.Flush()
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !containsLine(lines, "failed to read x1 in last 1s") || !containsLine(lines, "failed to write x2 in last 1s") {
+		t.Errorf("summaries = %q, want the summaries of both errors", lines)
+	}
+	buf.Reset()
+	r.Flush()
+	r.Close()
+	if buf.Len() != 0 {
+		t.Errorf("reports after the summaries = %q, want none", buf.String())
+	}
+}
+
+func containsLine(lines []string, line string) bool {
+	for _, l := range lines {
+		if l == line {
+			return true
+		}
+	}
+	return false
+}
+
+func TestReporterRateLimit(t *testing.T) {
+	r, buf, clock := newTestReporter(
+		WithWindow(time.Minute),
+		WithRateLimit(2, time.Second),
+		WithKindRateLimit(errReportWrite, 1, time.Second),
+	)
+	for i := 0; i < 4; i++ {
+		r.Report(New(errReportRead, WithErrorf("user %s", strings.Repeat("a", i+1))))
+		r.Report(New(errReportWrite, WithErrorf("user %s", strings.Repeat("a", i+1))))
+	}
+	clock.advance(time.Second)
+	r.Report(New(errReportWrite, WithErrorf("user b")))
+	want := strings.Join([]string{
+		"failed to read user a",
+		"failed to write user a",
+		"failed to read user aa",
+		"failed to write user b",
+	}, "\n") + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("reports = %q, want %q", got, want)
+	}
+	buf.Reset()
+	var summaries []Report
+	r.sinks = append(r.sinks, func(report Report) { summaries = append(summaries, report) })
+	r.Close()
+	var count uint64
+	for _, s := range summaries {
+		if !s.Summary {
+			t.Errorf("report %v is not a summary", s)
+		}
+		count += s.Count
+	}
+	if len(summaries) != 5 || count != 5 {
+		t.Errorf("summaries = %v, want 5 summaries of one suppressed error", summaries)
+	}
+}
+
+func TestReporterKindRateLimitHooks(t *testing.T) {
+	var calls int
+	defer OnNew(func(Grr, runtime.Frame) { calls++ })()
+	NewReporter(WithKindRateLimit(errReportWrite, 1, time.Second))
+	if calls != 0 {
+		t.Errorf("new hook calls = %d, want 0", calls)
+	}
+}
+
+func TestReporterSinks(t *testing.T) {
+	var logs bytes.Buffer
+	var reports []Report
+	clock := newTestClock()
+	r := NewReporter(
+		WithSink(SlogSink(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{
+			ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey || a.Key == "fingerprint" {
+					return slog.Attr{}
+				}
+				return a
+			},
+		})), slog.LevelError)),
+		WithSink(func(report Report) { reports = append(reports, report) }),
+		WithReporterClock(clock),
+	)
+	err := New(errReportRead)
+	r.Report(err)
+	r.Report(err)
+	r.Close()
+	fp := Fingerprint(err)
+	want := []Report{
+		{Err: err, Fingerprint: fp, Kind: "failed to read", Count: 1, Time: clock.now},
+		{Err: err, Fingerprint: fp, Kind: "failed to read", Summary: true, Count: 1, Window: 10 * time.Second, Time: clock.now},
+	}
+	if !reflect.DeepEqual(reports, want) {
+		t.Errorf("reports = %+v, want %+v", reports, want)
+	}
+	wantLogs := `level=ERROR msg="failed to read" error.msg="failed to read" error.kind="failed to read"` + "\n" +
+		`level=ERROR msg="failed to read x1 in last 10s" error.msg="failed to read" error.kind="failed to read" count=1 window=10s` + "\n"
+	if got := logs.String(); got != wantLogs {
+		t.Errorf("logs = %q, want %q", got, wantLogs)
+	}
+}
+
+func TestReporterRunClock(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var reports []Report
+	r, _, _ := newTestReporter(WithWindow(0), WithWindow(-time.Second), WithSink(func(report Report) {
+		reports = append(reports, report)
+		if report.Summary {
+			cancel()
+		}
+	}))
+	r.Report(New(errReportRead))
+	r.Report(New(errReportRead))
+	r.Run(ctx)
+	if len(reports) != 2 || !reports[1].Summary || reports[1].Window != defaultReportWindow {
+		t.Errorf("reports = %+v, want the error and its summary over %v", reports, defaultReportWindow)
+	}
+}
+
+func TestReporterExpire(t *testing.T) {
+	var calls int
+	defer OnAsGrr(func(Grr, runtime.Frame) { calls++ })()
+	r, _, clock := newTestReporter(WithRateLimit(1, time.Second), WithReporterNormalizers(func(s string) string { return s }))
+	for i := 0; i < 100; i++ {
+		r.Report(fmt.Errorf("cannot read group %d", i))
+	}
+	if len(r.windows) != 100 {
+		t.Fatalf("windows = %d, want 100", len(r.windows))
+	}
+	clock.advance(defaultReportWindow)
+	r.Report(errReportRead)
+	if len(r.windows) != 1 || len(r.rates) != 1 {
+		t.Errorf("windows = %d, rates = %d, want 1 of each once the others are over", len(r.windows), len(r.rates))
+	}
+	if calls != 0 {
+		t.Errorf("asgrr hook calls = %d, want 0", calls)
+	}
+}
+
+func TestReporterRedactedKind(t *testing.T) {
+	var reports []Report
+	r := NewReporter(WithSink(func(report Report) { reports = append(reports, report) }))
+	r.Report(New(errors.New("dial postgres://bob:hunter2@db:5432 failed"), WithRedaction(DefaultRedaction())))
+	r.Close()
+	if len(reports) == 0 {
+		t.Fatal("no reports, want one")
+	}
+	if want := "dial postgres://[REDACTED]@db:5432 failed"; reports[0].Kind != want {
+		t.Errorf("report kind = %q, want %q", reports[0].Kind, want)
+	}
+}
+
+func TestReporterRun(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewReporter(WithSink(WriterSink(&buf)), WithWindow(time.Hour))
+	r.Report(New(errReportRead))
+	r.Report(New(errReportRead))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r.Run(ctx)
+	if got, want := buf.String(), "failed to read\nfailed to read x1 in last 1h0m0s\n"; got != want {
+		t.Errorf("reports = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,103 @@
+package gerr
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrRetriesExhausted is the kind of the error returned by Retry when the operation kept failing with retryable errors
+// until the attempts ran out, the last error is part of its chain
+var ErrRetriesExhausted = errors.New("retries exhausted")
+
+// Clock abstracts the passing of time for Retry, Grouper and Reporter, so that the backoff, the times of the groups,
+// the windows and the rate limits can be tested without waiting
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// After returns a channel that receives the current time once the given duration has elapsed
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the Clock backed by the time package
+type realClock struct{}
+
+// Now implements the Clock interface
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// After implements the Clock interface
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// RetryOpt is the functional type for configuring Retry
+type RetryOpt func(c *retryConfig)
+
+// retryConfig is the configuration of Retry
+type retryConfig struct {
+	attempts int
+	initial  time.Duration
+	max      time.Duration
+	clock    Clock
+}
+
+// WithAttempts sets the maximum amount of times the operation is called, 3 by default
+func WithAttempts(n int) RetryOpt {
+	return func(c *retryConfig) {
+		c.attempts = n
+	}
+}
+
+// WithBackoff sets the delay before the first retry, which doubles on every retry up to the given maximum, 100ms and
+// 5s by default
+func WithBackoff(initial, max time.Duration) RetryOpt {
+	return func(c *retryConfig) {
+		c.initial = initial
+		c.max = max
+	}
+}
+
+// WithClock sets the clock used to wait between attempts
+func WithClock(clock Clock) RetryOpt {
+	return func(c *retryConfig) {
+		c.clock = clock
+	}
+}
+
+// Retry calls the operation until it succeeds, fails with an error that is not retryable, see IsRetryable, or the
+// attempts run out, waiting with an exponential backoff between attempts. The error that is not retryable is returned
+// as is, when the attempts run out an error of the kind ErrRetriesExhausted wrapping the last error is returned, it has
+// the Permanent trait so that it is not retried again by an outer Retry, and when the context is done while waiting a
+// permanent error of the kind of the context error wrapping the last error is returned.
+func Retry(ctx context.Context, op func(ctx context.Context) error, opts ...RetryOpt) error {
+	c := retryConfig{
+		attempts: 3,
+		initial:  100 * time.Millisecond,
+		max:      5 * time.Second,
+		clock:    realClock{},
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	delay := c.initial
+	for attempt := 1; ; attempt++ {
+		err := op(ctx)
+		if err == nil || !IsRetryable(err) {
+			return err
+		}
+		if attempt >= c.attempts {
+			return New(ErrRetriesExhausted, WithTraits(Permanent), chainErr(err))
+		}
+		select {
+		case <-ctx.Done():
+			return New(ctx.Err(), WithTraits(Permanent), chainErr(err))
+		case <-c.clock.After(delay):
+		}
+		delay *= 2
+		if delay > c.max {
+			delay = c.max
+		}
+	}
+}
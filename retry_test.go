@@ -0,0 +1,140 @@
+package gerr
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testClock is a Clock that only moves when it is advanced, or when it is waited on, which fires immediately unless
+// it is blocked, the durations it was asked to wait are recorded
+type testClock struct {
+	now     time.Time
+	waits   []time.Duration
+	blocked bool
+}
+
+// newTestClock returns a testClock starting at a fixed time
+func newTestClock() *testClock {
+	return &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+// Now implements the Clock interface
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+// After implements the Clock interface
+func (c *testClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	if c.blocked {
+		return nil
+	}
+	c.advance(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+// advance moves the clock forward by the given duration
+func (c *testClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestRetry(t *testing.T) {
+	errTemporary := errors.New("connection reset")
+	errPermanent := errors.New("invalid request")
+	tests := []struct {
+		name      string
+		errs      []error
+		opts      []RetryOpt
+		wantCalls int
+		wantWaits []time.Duration
+		wantKind  error
+		wantIs    error
+	}{
+		{
+			name:      "Success",
+			errs:      []error{nil},
+			wantCalls: 1,
+		},
+		{
+			name:      "SuccessAfterRetries",
+			errs:      []error{New(errTemporary, WithTraits(Temporary)), New(errTemporary, WithTraits(Temporary)), nil},
+			wantCalls: 3,
+			wantWaits: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name:      "NotRetryable",
+			errs:      []error{New(errPermanent, WithTraits(Permanent))},
+			wantCalls: 1,
+			wantKind:  errPermanent,
+		},
+		{
+			name:      "Unclassified",
+			errs:      []error{errPermanent},
+			wantCalls: 1,
+			wantKind:  errPermanent,
+		},
+		{
+			name:      "Exhausted",
+			errs:      []error{Classify(errTemporary, Temporary)},
+			opts:      []RetryOpt{WithAttempts(5), WithBackoff(time.Second, 3*time.Second)},
+			wantCalls: 5,
+			wantWaits: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second},
+			wantKind:  ErrRetriesExhausted,
+			wantIs:    errTemporary,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newTestClock()
+			calls := 0
+			err := Retry(context.Background(), func(ctx context.Context) error {
+				err := tt.errs[min(calls, len(tt.errs)-1)]
+				calls++
+				return err
+			}, append([]RetryOpt{WithClock(clock)}, tt.opts...)...)
+			if calls != tt.wantCalls {
+				t.Errorf("Retry() calls = %v, want %v", calls, tt.wantCalls)
+			}
+			if !reflect.DeepEqual(clock.waits, tt.wantWaits) {
+				t.Errorf("Retry() waits = %v, want %v", clock.waits, tt.wantWaits)
+			}
+			if tt.wantKind == nil {
+				if err != nil {
+					t.Errorf("Retry() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantKind) {
+				t.Errorf("Retry() error = %v, want %v", err, tt.wantKind)
+			}
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Errorf("Retry() error = %v, want it to wrap %v", err, tt.wantIs)
+			}
+			if IsRetryable(err) {
+				t.Errorf("IsRetryable(Retry()) = true, want false")
+			}
+		})
+	}
+}
+
+func TestRetryContextDone(t *testing.T) {
+	errTemporary := errors.New("connection reset")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	blocked := newTestClock()
+	blocked.blocked = true
+	err := Retry(ctx, func(ctx context.Context) error {
+		return Classify(errTemporary, Temporary)
+	}, WithClock(blocked))
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, errTemporary) {
+		t.Errorf("Retry() error = %v, want %v wrapping %v", err, context.Canceled, errTemporary)
+	}
+	if IsRetryable(err) {
+		t.Errorf("IsRetryable(Retry()) = true, want false")
+	}
+}
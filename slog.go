@@ -0,0 +1,48 @@
+package gerr
+
+import (
+	"log/slog"
+	"strconv"
+)
+
+// LogValue implements the slog.LogValuer interface, see logValue
+func (k kind) LogValue() slog.Value {
+	return logValue(k, k.toJSON())
+}
+
+// LogValue implements the slog.LogValuer interface, see logValue
+func (w wrapped) LogValue() slog.Value {
+	return logValue(w, w.toJSON())
+}
+
+// LogValue implements the slog.LogValuer interface, every branch is logged as a group keyed by its index
+func (j joined) LogValue() slog.Value {
+	attrs := make([]slog.Attr, len(j.errs))
+	for i, g := range j.errs {
+		attrs[i] = slog.Any(strconv.Itoa(i), g)
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// logValue returns the group logged for the given error, made of its message, its kind, its code, the chain below the
+// kind as an array and its fields as a group, the attributes without a value are left out
+func logValue(g Grr, j jsonGrr) slog.Value {
+	attrs := []slog.Attr{
+		slog.String("msg", g.Error()),
+		slog.String("kind", j.Kind),
+	}
+	if j.Code != "" {
+		attrs = append(attrs, slog.String("code", j.Code))
+	}
+	if len(j.Chain) > 0 {
+		attrs = append(attrs, slog.Any("chain", j.Chain))
+	}
+	if len(j.Fields) > 0 {
+		fields := make([]slog.Attr, len(j.Fields))
+		for i, f := range j.Fields {
+			fields[i] = slog.Any(f.Key, f.Value)
+		}
+		attrs = append(attrs, slog.Attr{Key: "fields", Value: slog.GroupValue(fields...)})
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,61 @@
+package gerr
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// logJSON logs the error with the slog JSON handler and returns the line written, without the builtin attributes
+func logJSON(err error) string {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("", "err", err)
+	return strings.TrimSpace(buf.String())
+}
+
+func TestLogValue(t *testing.T) {
+	sep := _separator()
+	tests := []struct {
+		name string
+		got  Grr
+		want string
+	}{
+		{
+			name: "Kind",
+			got:  New(errors.New("one")),
+			want: `{"err":{"msg":"one","kind":"one"}}`,
+		},
+		{
+			name: "Wrapped",
+			got:  New(errors.New("one"), WithField("user", "someUser")).Add(errors.New("two")),
+			want: `{"err":{"msg":"one` + sep + `two","kind":"one","chain":["two"],"fields":{"user":"someUser"}}}`,
+		},
+		{
+			name: "Code",
+			got:  New(errRegistryRead),
+			want: `{"err":{"msg":"registry failed to read","kind":"registry failed to read","code":"registry.read"}}`,
+		},
+		{
+			name: "Joined",
+			got:  Join(New(errors.New("one")), New(errors.New("two"))),
+			want: `{"err":{"0":{"msg":"one","kind":"one"},"1":{"msg":"two","kind":"two"}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := logJSON(tt.got); got != tt.want {
+				t.Errorf("LogValue() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
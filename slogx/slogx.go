@@ -0,0 +1,112 @@
+// Package slogx provides a slog.Handler that expands the gerr errors found in the attributes of the records into
+// groups, see gerr's LogValue implementations, and that sanitizes them before they are logged in production.
+package slogx
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/insan1k/gerr"
+)
+
+// Handler wraps a slog.Handler expanding the errors found in the attributes, at any depth, into groups
+type Handler struct {
+	next     slog.Handler
+	sanitize bool
+	foreign  bool
+}
+
+// Option is the functional type for configuring a Handler
+type Option func(h *Handler)
+
+// WithSanitize configures the Handler to sanitize the errors before they are logged, so that only their kind and public
+// fields are logged, which is what production should log
+func WithSanitize() Option {
+	return func(h *Handler) {
+		h.sanitize = true
+	}
+}
+
+// WithForeignErrors configures the Handler to convert the errors that are not gerr errors using gerr.AsGrr, so that
+// they are expanded as well
+func WithForeignErrors() Option {
+	return func(h *Handler) {
+		h.foreign = true
+	}
+}
+
+// NewHandler returns a new Handler wrapping the given handler and configured with the given options
+func NewHandler(next slog.Handler, opts ...Option) *Handler {
+	h := &Handler{next: next}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// NewProductionHandler returns a new Handler wrapping the given handler that sanitizes all the errors, including the
+// ones that are not gerr errors
+func NewProductionHandler(next slog.Handler) *Handler {
+	return NewHandler(next, WithSanitize(), WithForeignErrors())
+}
+
+// Enabled implements the slog.Handler interface
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements the slog.Handler interface, the record is copied with its attributes expanded
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	expanded := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		expanded.AddAttrs(h.expand(a))
+		return true
+	})
+	return h.next.Handle(ctx, expanded)
+}
+
+// WithAttrs implements the slog.Handler interface
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		expanded[i] = h.expand(a)
+	}
+	return &Handler{next: h.next.WithAttrs(expanded), sanitize: h.sanitize, foreign: h.foreign}
+}
+
+// WithGroup implements the slog.Handler interface
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: h.next.WithGroup(name), sanitize: h.sanitize, foreign: h.foreign}
+}
+
+// expand replaces the errors of the attribute, and of the groups it holds, by their log value
+func (h *Handler) expand(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		group := a.Value.Group()
+		attrs := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			attrs[i] = h.expand(ga)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}
+	case slog.KindAny, slog.KindLogValuer:
+		err, ok := a.Value.Any().(error)
+		if !ok {
+			return a
+		}
+		g, ok := err.(gerr.Grr)
+		if !ok {
+			if !h.foreign {
+				return a
+			}
+			g = gerr.AsGrr(err)
+		}
+		if h.sanitize {
+			g = g.Sanitize()
+		}
+		if v, ok := g.(slog.LogValuer); ok {
+			return slog.Attr{Key: a.Key, Value: v.LogValue()}
+		}
+	}
+	return a
+}
@@ -0,0 +1,89 @@
+package slogx
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/insan1k/gerr"
+)
+
+// newTestLogger returns a logger writing JSON lines to the given buffer through the handler built by wrap, without the
+// builtin attributes
+func newTestLogger(buf *bytes.Buffer, wrap func(next slog.Handler) slog.Handler) *slog.Logger {
+	next := slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	return slog.New(wrap(next))
+}
+
+func TestHandler(t *testing.T) {
+	g := gerr.New(errors.New("one"), gerr.WithField("user", "someUser"), gerr.WithPublicField("op", "read")).
+		Add(errors.New("two"))
+	foreign := errors.New("foreign")
+	tests := []struct {
+		name string
+		wrap func(next slog.Handler) slog.Handler
+		log  func(l *slog.Logger)
+		want string
+	}{
+		{
+			name: "Expand",
+			wrap: func(next slog.Handler) slog.Handler { return NewHandler(next) },
+			log:  func(l *slog.Logger) { l.Info("", "err", g) },
+			want: `{"err":{"msg":"one two","kind":"one","chain":["two"],"fields":{"user":"someUser","op":"read"}}}`,
+		},
+		{
+			name: "Sanitize",
+			wrap: func(next slog.Handler) slog.Handler { return NewHandler(next, WithSanitize()) },
+			log:  func(l *slog.Logger) { l.Info("", "err", g) },
+			want: `{"err":{"msg":"one","kind":"one","fields":{"op":"read"}}}`,
+		},
+		{
+			name: "Group",
+			wrap: func(next slog.Handler) slog.Handler { return NewHandler(next, WithSanitize()) },
+			log:  func(l *slog.Logger) { l.Info("", slog.Group("req", "err", g)) },
+			want: `{"req":{"err":{"msg":"one","kind":"one","fields":{"op":"read"}}}}`,
+		},
+		{
+			name: "WithAttrs",
+			wrap: func(next slog.Handler) slog.Handler { return NewHandler(next, WithSanitize()) },
+			log:  func(l *slog.Logger) { l.With("err", g).Info("") },
+			want: `{"err":{"msg":"one","kind":"one","fields":{"op":"read"}}}`,
+		},
+		{
+			name: "WithGroup",
+			wrap: func(next slog.Handler) slog.Handler { return NewHandler(next, WithSanitize()) },
+			log:  func(l *slog.Logger) { l.WithGroup("req").Info("", "err", g) },
+			want: `{"req":{"err":{"msg":"one","kind":"one","fields":{"op":"read"}}}}`,
+		},
+		{
+			name: "ForeignKept",
+			wrap: func(next slog.Handler) slog.Handler { return NewHandler(next) },
+			log:  func(l *slog.Logger) { l.Info("", "err", foreign) },
+			want: `{"err":"foreign"}`,
+		},
+		{
+			name: "Production",
+			wrap: func(next slog.Handler) slog.Handler { return NewProductionHandler(next) },
+			log:  func(l *slog.Logger) { l.Info("", "err", foreign, "user", "someUser") },
+			want: `{"err":{"msg":"foreign","kind":"foreign"},"user":"someUser"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.log(newTestLogger(&buf, tt.wrap))
+			if got := strings.TrimSpace(buf.String()); got != tt.want {
+				t.Errorf("Handle() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
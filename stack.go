@@ -0,0 +1,119 @@
+package gerr
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// maxStackDepth is the maximum amount of frames captured for a stack trace
+const maxStackDepth = 32
+
+// packagePrefix is the prefix of the functions of this package, used to remove them from the top of the stack traces
+var packagePrefix = func() string {
+	name := runtime.FuncForPC(reflect.ValueOf(callers).Pointer()).Name()
+	return name[:strings.LastIndex(name, ".")+1]
+}()
+
+// stack holds the program counters of a stack trace, they are only symbolized when the stack trace is requested, so
+// that capturing it stays cheap
+type stack struct {
+	pcs []uintptr
+	// panicked is set when the stack trace was captured while panicking, see panicCallers
+	panicked   bool
+	once       sync.Once
+	symbolized []runtime.Frame
+}
+
+// callers captures the stack trace of the calling goroutine
+func callers() *stack {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(2, pcs[:])
+	return &stack{pcs: pcs[:n]}
+}
+
+// frames symbolizes the stack trace, the frames belonging to this package at the top of the stack are removed so that
+// the first frame is the caller of the package, as well as the frames of the runtime when the stack trace was captured
+// while panicking
+func (s *stack) frames() []runtime.Frame {
+	if s == nil {
+		return nil
+	}
+	s.once.Do(func() {
+		it := runtime.CallersFrames(s.pcs)
+		top := true
+		for {
+			f, more := it.Next()
+			if !top || !(isPackageFrame(f) || s.panicked && isRuntimeFrame(f)) {
+				top = false
+				s.symbolized = append(s.symbolized, f)
+			}
+			if !more {
+				break
+			}
+		}
+	})
+	return s.symbolized
+}
+
+// isPackageFrame reports whether the frame belongs to the non test code of this package
+func isPackageFrame(f runtime.Frame) bool {
+	return strings.HasPrefix(f.Function, packagePrefix) && !strings.HasSuffix(f.File, "_test.go")
+}
+
+// isRuntimeFrame reports whether the frame belongs to the runtime package
+func isRuntimeFrame(f runtime.Frame) bool {
+	return strings.HasPrefix(f.Function, "runtime.")
+}
+
+// writeStack writes the frames of the given stack trace, one function and its location per frame
+func writeStack(w io.Writer, s *stack) {
+	for _, f := range s.frames() {
+		_, _ = fmt.Fprintf(w, "\n%s\n\t%s:%d", f.Function, f.File, f.Line)
+	}
+}
+
+// shouldCaptureStack reports whether a stack trace should be captured for an error added to the given kind, which is
+// the case when the kind captured its own stack trace
+func shouldCaptureStack(k kind) bool {
+	return k.stack != nil
+}
+
+// format implements the fmt.Formatter interface for the package types, %v and %s write the error message, %q writes
+// it quoted and %+v writes it followed by the layers of its chain, one per line, its fields, redacted by the given rule,
+// and the given stack trace
+func format(s fmt.State, verb rune, g Grr, st *stack, redact RedactRule) {
+	switch verb {
+	case 'v':
+		_, _ = io.WriteString(s, g.Error())
+		if s.Flag('+') {
+			writeDetails(s, g, st, redact)
+		}
+	case 's':
+		_, _ = io.WriteString(s, g.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", g.Error())
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(%s)", verb, g.Error())
+	}
+}
+
+// writeDetails writes the multi-line rendering of the %+v verb, each section is only written when it is not empty
+func writeDetails(w io.Writer, g Grr, st *stack, redact RedactRule) {
+	if chain := g.Chain(); len(chain) > 1 {
+		_, _ = io.WriteString(w, "\nchain:")
+		for _, err := range chain {
+			_, _ = fmt.Fprintf(w, "\n\t%s", err.Error())
+		}
+	}
+	if fields := redact.fields(g.Fields()); len(fields) > 0 {
+		_, _ = fmt.Fprintf(w, "\nfields:%s", fieldsString(fields))
+	}
+	if len(st.frames()) > 0 {
+		_, _ = io.WriteString(w, "\nstack:")
+		writeStack(w, st)
+	}
+}
@@ -0,0 +1,135 @@
+package gerr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestStackTrace(t *testing.T) {
+	tests := []struct {
+		name      string
+		enable    bool
+		newGrr    func() Grr
+		wantStack bool
+	}{
+		{
+			name:      "Disabled",
+			newGrr:    func() Grr { return New(errors.New("one")) },
+			wantStack: false,
+		},
+		{
+			name:      "DisabledWrapped",
+			newGrr:    func() Grr { return New(errors.New("one")).Add(errors.New("two")) },
+			wantStack: false,
+		},
+		{
+			name:      "WithStackTrace",
+			newGrr:    func() Grr { return New(errors.New("one"), WithStackTrace()) },
+			wantStack: true,
+		},
+		{
+			name:      "WithStackTraceAndWithErr",
+			newGrr:    func() Grr { return New(errors.New("one"), WithStackTrace(), WithErr(errors.New("two"))) },
+			wantStack: true,
+		},
+		{
+			name:      "Errorf",
+			enable:    true,
+			newGrr:    func() Grr { return Errorf("one %v", 1) },
+			wantStack: true,
+		},
+		{
+			name:      "EnabledAdd",
+			enable:    true,
+			newGrr:    func() Grr { return New(errors.New("one")).Add(errors.New("two")) },
+			wantStack: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.enable {
+				EnableStackTrace()
+				defer DisableStackTrace()
+			}
+			got := tt.newGrr().StackTrace()
+			if (len(got) > 0) != tt.wantStack {
+				t.Fatalf("StackTrace() = %v, want stack %v", got, tt.wantStack)
+			}
+			if tt.wantStack && !strings.HasPrefix(got[0].Function, packagePrefix+"TestStackTrace") {
+				t.Errorf("StackTrace()[0] = %v, want the caller of the package", got[0].Function)
+			}
+		})
+	}
+}
+
+func TestStackTraceAdd(t *testing.T) {
+	w := New(errors.New("one"), WithStackTrace()).Add(errors.New("two")).(wrapped)
+	l, ok := w.err.(layer)
+	if !ok || l.stack == nil {
+		t.Fatalf("Add() = %#v, want a layer with a stack trace", w.err)
+	}
+	if f := l.stack.frames(); len(f) == 0 || !strings.HasPrefix(f[0].Function, packagePrefix+"TestStackTraceAdd") {
+		t.Errorf("Add() stack = %v, want the caller of Add", f)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	g := New(errors.New("one"), WithStackTrace()).Add(errors.New("two"))
+	sep := _separator()
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{name: "v", format: "%v", want: "one" + sep + "two"},
+		{name: "s", format: "%s", want: "one" + sep + "two"},
+		{name: "q", format: "%q", want: fmt.Sprintf("%q", "one"+sep+"two")},
+		{name: "+v", format: "%+v", want: "one" + sep + "two\nchain:\n\tone\n\ttwo\nstack:\n" + packagePrefix + "TestFormat"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, g); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("Sprintf(%v) = %v, want %v", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDetails(t *testing.T) {
+	sep := _separator()
+	tests := []struct {
+		name string
+		err  Grr
+		want string
+	}{
+		{
+			name: "Kind",
+			err:  New(errors.New("one")),
+			want: "one",
+		},
+		{
+			name: "Chain",
+			err:  New(errors.New("one"), WithErr(errors.New("two")), WithErr(errors.New("three"))),
+			want: "one" + sep + "three" + sep + "two\nchain:\n\tone\n\tthree\n\ttwo",
+		},
+		{
+			name: "Fields",
+			err:  New(errors.New("one"), WithField("id", 1), WithPublicField("op", "read")),
+			want: "one\nfields: id=1 op=read",
+		},
+		{
+			name: "Joined",
+			err:  Join(New(errors.New("one"), WithErr(errors.New("two"))), New(errors.New("three"))),
+			want: "one" + sep + "two\nchain:\n\tone\n\ttwo\nthree",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprintf("%+v", tt.err); got != tt.want {
+				t.Errorf("Sprintf(%%+v) = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
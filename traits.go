@@ -0,0 +1,123 @@
+package gerr
+
+// Trait classifies errors to decide whether the operation that failed can be retried, traits are combined as flags
+type Trait uint8
+
+const (
+	// Temporary errors are expected to go away on their own
+	Temporary Trait = 1 << iota
+	// Timeout errors are caused by a deadline or a timeout being exceeded
+	Timeout
+	// Retryable errors can be retried as they are
+	Retryable
+	// Permanent errors must not be retried, the trait takes precedence over the other ones
+	Permanent
+)
+
+// WithTraits attaches the given traits to the kind of the package type Grr, the traits are kept when errors are added
+// to the Grr
+func WithTraits(traits ...Trait) Option {
+	return func(w wrapped) wrapped {
+		w.kind = w.kind.withExtras(func(e *kindExtras) {
+			e.traits |= combineTraits(traits)
+		})
+		return w
+	}
+}
+
+// Classify returns the given error with the given traits attached, so that they can be attached to any layer of a
+// chain, the returned error wraps the given one so that it is still matched by errors.Is and errors.As
+func Classify(err error, traits ...Trait) error {
+	if err == nil {
+		return nil
+	}
+	return classified{err: err, traits: combineTraits(traits)}
+}
+
+// TraitsOf returns the traits of the error, which are the traits of the top most error of its chain that has any,
+// besides the traits attached by this package, errors with a Timeout() bool method returning true, such as net.Error,
+// context.DeadlineExceeded and os.ErrDeadlineExceeded, have the Timeout trait, while errors with a Temporary() bool
+// method returning true have the Temporary trait
+func TraitsOf(err error) Trait {
+	var traits Trait
+	walkDepthFirst(err, func(e error, _ []error) {
+		if traits == 0 {
+			traits = ownTraits(e)
+		}
+	})
+	return traits
+}
+
+// HasTrait reports whether the error has the given trait, see TraitsOf
+func HasTrait(err error, trait Trait) bool {
+	return TraitsOf(err)&trait != 0
+}
+
+// IsRetryable reports whether the operation that failed with the error can be retried, which is the case when the
+// error is temporary, a timeout or retryable, unless it is permanent, see TraitsOf
+func IsRetryable(err error) bool {
+	traits := TraitsOf(err)
+	return traits&Permanent == 0 && traits&(Temporary|Timeout|Retryable) != 0
+}
+
+// combineTraits combines the given traits into a single set of flags
+func combineTraits(traits []Trait) Trait {
+	var combined Trait
+	for _, t := range traits {
+		combined |= t
+	}
+	return combined
+}
+
+// ownTraits returns the traits of the error alone, the errors wrapped by it are not considered, except for the errors
+// held by the package types that are not part of the chain walked through Unwrap
+func ownTraits(err error) Trait {
+	switch e := err.(type) {
+	case kind:
+		return e.ownTraits()
+	case wrapped:
+		return e.kind.ownTraits()
+	case layer:
+		if e.orig == nil {
+			return 0
+		}
+		if e.shallow {
+			return ownTraits(e.orig)
+		}
+		return TraitsOf(e.orig)
+	case classified:
+		return e.traits
+	}
+	var traits Trait
+	if t, ok := err.(interface{ Timeout() bool }); ok && t.Timeout() {
+		traits |= Timeout
+	}
+	if t, ok := err.(interface{ Temporary() bool }); ok && t.Temporary() {
+		traits |= Temporary
+	}
+	return traits
+}
+
+// ownTraits returns the traits attached to the kind, or the traits of its error when none were attached
+func (k kind) ownTraits() Trait {
+	if traits := k.ext().traits; traits != 0 {
+		return traits
+	}
+	return TraitsOf(k.err)
+}
+
+// classified is an error with traits attached, see Classify
+type classified struct {
+	err    error
+	traits Trait
+}
+
+// Error implements the error interface
+func (c classified) Error() string {
+	return c.err.Error()
+}
+
+// Unwrap implements the Unwrap interface
+func (c classified) Unwrap() error {
+	return c.err
+}
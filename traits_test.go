@@ -0,0 +1,116 @@
+package gerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+)
+
+func TestTraitsOf(t *testing.T) {
+	errTemporary := errors.New("connection reset")
+	errPermanent := errors.New("invalid request")
+	netTimeout := &net.DNSError{Err: "i/o timeout", IsTimeout: true}
+	tests := []struct {
+		name          string
+		err           error
+		want          Trait
+		wantRetryable bool
+	}{
+		{
+			name: "Nil",
+		},
+		{
+			name: "Unclassified",
+			err:  New(errors.New("one")),
+		},
+		{
+			name:          "Kind",
+			err:           New(errTemporary, WithTraits(Temporary)),
+			want:          Temporary,
+			wantRetryable: true,
+		},
+		{
+			name:          "InheritedThroughAdd",
+			err:           New(errTemporary, WithTraits(Temporary, Retryable)).Add(errors.New("two")),
+			want:          Temporary | Retryable,
+			wantRetryable: true,
+		},
+		{
+			name:          "Layer",
+			err:           New(errors.New("failed to read")).Add(Classify(errTemporary, Retryable)),
+			want:          Retryable,
+			wantRetryable: true,
+		},
+		{
+			name: "KindTakesPrecedence",
+			err:  New(errPermanent, WithTraits(Permanent), WithErr(Classify(errTemporary, Temporary))),
+			want: Permanent,
+		},
+		{
+			name: "PermanentWins",
+			err:  New(errPermanent, WithTraits(Permanent, Retryable)),
+			want: Permanent | Retryable,
+		},
+		{
+			name:          "ClassifiedKind",
+			err:           New(Classify(errTemporary, Temporary)),
+			want:          Temporary,
+			wantRetryable: true,
+		},
+		{
+			name:          "NetError",
+			err:           New(errors.New("failed to resolve"), WithErr(netTimeout)),
+			want:          Timeout | Temporary,
+			wantRetryable: true,
+		},
+		{
+			name:          "ContextDeadlineExceeded",
+			err:           fmt.Errorf("query: %w", context.DeadlineExceeded),
+			want:          Timeout | Temporary,
+			wantRetryable: true,
+		},
+		{
+			name:          "OSDeadlineExceeded",
+			err:           New(errors.New("failed to read")).Add(os.ErrDeadlineExceeded),
+			want:          Timeout | Temporary,
+			wantRetryable: true,
+		},
+		{
+			name: "ContextCanceled",
+			err:  context.Canceled,
+		},
+		{
+			name:          "Joined",
+			err:           Join(New(errors.New("one")), New(errTemporary, WithTraits(Temporary))),
+			want:          Temporary,
+			wantRetryable: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TraitsOf(tt.err); got != tt.want {
+				t.Errorf("TraitsOf() = %b, want %b", got, tt.want)
+			}
+			if got := IsRetryable(tt.err); got != tt.wantRetryable {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.wantRetryable)
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	errTemporary := errors.New("connection reset")
+	if Classify(nil, Temporary) != nil {
+		t.Errorf("Classify(nil) != nil")
+	}
+	err := Classify(errTemporary, Temporary)
+	if !errors.Is(err, errTemporary) || err.Error() != errTemporary.Error() {
+		t.Errorf("Classify() = %v, want an error matching %v", err, errTemporary)
+	}
+	if !HasTrait(err, Temporary) || HasTrait(err, Permanent) {
+		t.Errorf("HasTrait() = %b, want %b", TraitsOf(err), Temporary)
+	}
+}
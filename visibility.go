@@ -0,0 +1,66 @@
+package gerr
+
+// Visibility is the audience an entry of the error chain can be exposed to, the zero value is Internal, so that the
+// entries are never exposed to the public unless requested, the visibilities are ordered from the widest audience to
+// the narrowest one
+type Visibility int
+
+const (
+	// Public entries can be exposed to the clients of an API
+	Public Visibility = iota - 1
+	// Internal entries can be exposed to the operators of a service, in logs for instance, this is the default
+	Internal
+	// Secret entries must not leave the process, they may hold connection strings or credentials
+	Secret
+)
+
+// String returns the name of the visibility
+func (v Visibility) String() string {
+	switch v {
+	case Public:
+		return "public"
+	case Internal:
+		return "internal"
+	case Secret:
+		return "secret"
+	}
+	return "unknown"
+}
+
+// visibilityOf returns the visibility given to Add or WithErr, or Internal when none was given
+func visibilityOf(v []Visibility) Visibility {
+	if len(v) == 0 {
+		return Internal
+	}
+	return v[0]
+}
+
+// sanitizeLayers returns a copy of the given chain of layers holding only the entries visible to the given audience,
+// errors that are not layers hold no visibility and are considered internal
+func sanitizeLayers(err error, audience Visibility) error {
+	if err == nil {
+		return nil
+	}
+	l, ok := err.(layer)
+	if !ok {
+		if Internal <= audience {
+			return err
+		}
+		return nil
+	}
+	next := sanitizeLayers(l.next, audience)
+	if l.visibility > audience {
+		return next
+	}
+	l.next = next
+	return l
+}
+
+// sanitizeFields returns the fields visible to the given audience, public fields are visible to every audience while
+// the remaining fields are internal
+func sanitizeFields(fields []Field, audience Visibility) []Field {
+	if Internal <= audience {
+		return fields
+	}
+	return publicFields(fields)
+}
@@ -0,0 +1,111 @@
+package gerr
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSanitizeTo(t *testing.T) {
+	sep := _separator()
+	errKind := errors.New("failed to parse")
+	errReason := errors.New("invalid user id")
+	errDB := errors.New("cannot connect to sql://someUser@host")
+	errQuery := errors.New("select failed")
+	g := New(errKind, WithErr(errDB, Secret), WithField("user", "someUser"), WithPublicField("op", "parse")).
+		Add(errQuery).
+		Add(errReason, Public)
+	tests := []struct {
+		name       string
+		got        Grr
+		audience   Visibility
+		wantError  string
+		wantFields []Field
+		wantIs     []error
+		wantIsNot  []error
+	}{
+		{
+			name:       "Public",
+			got:        g,
+			audience:   Public,
+			wantError:  "failed to parse" + sep + "invalid user id",
+			wantFields: []Field{{Key: "op", Value: "parse", Public: true}},
+			wantIs:     []error{errKind, errReason},
+			wantIsNot:  []error{errQuery, errDB},
+		},
+		{
+			name:      "Internal",
+			got:       g,
+			audience:  Internal,
+			wantError: "failed to parse" + sep + "invalid user id" + sep + "select failed",
+			wantFields: []Field{
+				{Key: "user", Value: "someUser"},
+				{Key: "op", Value: "parse", Public: true},
+			},
+			wantIs:    []error{errKind, errReason, errQuery},
+			wantIsNot: []error{errDB},
+		},
+		{
+			name:      "Secret",
+			got:       g,
+			audience:  Secret,
+			wantError: g.Error(),
+			wantFields: []Field{
+				{Key: "user", Value: "someUser"},
+				{Key: "op", Value: "parse", Public: true},
+			},
+			wantIs: []error{errKind, errReason, errQuery, errDB},
+		},
+		{
+			name:      "DefaultInternal",
+			got:       New(errKind, WithErr(errQuery)),
+			audience:  Public,
+			wantError: "failed to parse",
+			wantIs:    []error{errKind},
+			wantIsNot: []error{errQuery},
+		},
+		{
+			name:      "KindAdd",
+			got:       New(errKind).Add(errReason, Public),
+			audience:  Public,
+			wantError: "failed to parse" + sep + "invalid user id",
+			wantIs:    []error{errKind, errReason},
+		},
+		{
+			name:      "Joined",
+			got:       Join(New(errKind).Add(errReason, Public), New(errKind).Add(errQuery)),
+			audience:  Public,
+			wantError: "failed to parse" + sep + "invalid user id\nfailed to parse",
+			wantIs:    []error{errKind, errReason},
+			wantIsNot: []error{errQuery},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.got.SanitizeTo(tt.audience)
+			if got.Error() != tt.wantError {
+				t.Errorf("SanitizeTo() = %v, want %v", got.Error(), tt.wantError)
+			}
+			if fields := got.Fields(); !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("SanitizeTo() fields = %v, want %v", fields, tt.wantFields)
+			}
+			for _, err := range tt.wantIs {
+				if !got.Is(err) {
+					t.Errorf("SanitizeTo().Is(%v) = false, want true", err)
+				}
+			}
+			for _, err := range tt.wantIsNot {
+				if got.Is(err) {
+					t.Errorf("SanitizeTo().Is(%v) = true, want false", err)
+				}
+			}
+		})
+	}
+}
+
+func TestSanitizeIsSanitizeToPublic(t *testing.T) {
+	g := New(errors.New("one"), WithPublicField("op", "read")).Add(errors.New("two"), Public).Add(errors.New("three"))
+	if got, want := g.Sanitize(), g.SanitizeTo(Public); !equalGrr(got, want) {
+		t.Errorf("Sanitize() = %v, want %v", got, want)
+	}
+}
@@ -1,75 +1,189 @@
 package gerr
 
 import (
+	"errors"
 	"fmt"
+	"runtime"
 )
 
-// newWrapped returns the error as a new package type Wrapped
-func newWrapped(k error, opts ...Option) wrapped {
-	sep := _separator()
-	k, opts = newWrappedFromWrappedError(k, sep, opts...)
-	w := wrapped{
-		kind: kind{
-			err:       k,
-			separator: sep,
-		},
+// newWrapped returns the error as a new package type Wrapped configured with the defaults of the given Config, the add
+// hooks are fired once all the options ran when any of them added an error to the chain
+func newWrapped(c Config, k error, opts ...Option) wrapped {
+	w, added := newWrappedFromWrappedError(k, c.kind()).apply(opts)
+	if w.kind.stack == nil && c.StackTrace {
+		w.kind.stack = callers()
 	}
+	if added {
+		fire(hookAdd, w)
+	}
+	return w
+}
+
+// apply applies the given options, the second value reports whether any of them added an error to the chain
+func (w wrapped) apply(opts []Option) (wrapped, bool) {
 	for _, opt := range opts {
 		w = opt(w)
 	}
-	return w
+	added := w.added
+	w.added = false
+	return w, added
 }
 
-func newWrappedFromWrappedError(k error, sep string, opts ...Option) (error, []Option) {
+// newWrappedFromWrappedError builds the package type Wrapped from the given kind error, package types are reused as
+// they are, while errors that wrap other errors are split into layers, the top most layer becoming the kind and the
+// remaining layers becoming the chain, the original error values are kept on each layer, errors that wrap multiple
+// errors with a message of their own have that message as the kind and the errors they wrap as the branches of a
+// joined chain, the configuration of the new kind is taken from the given defaults
+func newWrappedFromWrappedError(k error, defaults kind) wrapped {
+	switch e := k.(type) {
+	case kind:
+		return wrapped{kind: e}
+	case wrapped:
+		return e
+	}
+	w := wrapped{kind: defaults}
+	w.kind.err = k
+	if _, ok := k.(interface {
+		Unwrap() []error
+	}); ok {
+		errs := unwrapAll(k)
+		if _, pure := ownMessages(k, errs); pure {
+			return w
+		}
+		if branches := joinBranches(errs, defaults); len(branches) > 0 {
+			w.kind.err = splitMultiWrapper(k, errs, defaults.separator)
+			w.err = newJoined(branches)
+		}
+		return w
+	}
 	if _, ok := k.(interface {
 		Unwrap() error
 	}); ok {
-		chain := Chain(k, WithTrimCustom(sep), WithBottomFirst())
-		if len(chain) > 1 {
-			var newOpts []Option
-			k = chain[len(chain)-1]
-			chain = chain[:len(chain)-1]
-			for i := 0; i < len(chain); i++ {
-				newOpts = append(newOpts, WithErr(chain[i]))
-			}
-			opts = append(newOpts, opts...)
+		layers := splitWrappedError(k, defaults.separator)
+		if len(layers) > 1 {
+			w.kind.err = layers[0]
+			w.err = linkLayers(layers[1:])
 		}
 	}
-	return k, opts
+	return w
 }
 
 type wrapped struct {
 	kind kind
 	err  error
+	// added is set by the options that add an error to the chain while they are applied, see apply
+	added bool
 }
 
-// Sanitize removes all additional context from the Grr
-func (w wrapped) Sanitize() Grr {
+// grr returns the error as the package type Wrapped if it contains a wrapped error, otherwise it returns its kind
+func (w wrapped) grr() Grr {
+	if w.err != nil {
+		return w
+	}
 	return w.kind
 }
 
-// Add adds the given error to the error chain
-func (w wrapped) Add(err error) Grr {
+// Sanitize removes all additional context from the Grr that is not public, see SanitizeTo
+func (w wrapped) Sanitize() Grr {
+	return w.SanitizeTo(Public)
+}
+
+// SanitizeTo removes the entries of the chain and the fields that are not visible to the given audience, the kind
+// itself is always kept, the retained entries keep their original error values so that they still match with Is
+func (w wrapped) SanitizeTo(audience Visibility) Grr {
+	return fire(hookSanitize, w.sanitizeTo(audience))
+}
+
+// sanitizeTo removes the entries of the chain and the fields that are not visible to the given audience without firing
+// the hooks
+func (w wrapped) sanitizeTo(audience Visibility) Grr {
+	return wrapped{
+		kind: w.kind.sanitizeTo(audience),
+		err:  sanitizeLayers(w.err, audience),
+	}.grr()
+}
+
+// Add adds the given error to the error chain, the given error is kept as is so that it can later be matched by
+// identity, the entry is internal unless another visibility is given
+func (w wrapped) Add(err error, v ...Visibility) Grr {
+	if err == nil {
+		return w
+	}
+	return fire(hookAdd, w.add(err, v))
+}
+
+// add adds the given error to the error chain without firing the hooks
+func (w wrapped) add(err error, v []Visibility) wrapped {
+	if err == nil {
+		return w
+	}
+	l := layer{
+		orig:       err,
+		separator:  w.kind.separator,
+		next:       w.err,
+		visibility: visibilityOf(v),
+	}
+	if shouldCaptureStack(w.kind) {
+		l.stack = callers()
+	}
 	return wrapped{
-		kind: kind{
-			err:       w.kind.err,
-			separator: w.kind.separator,
-		},
-		err: fmt.Errorf("%v%s%w", err, w.kind.separator, w.err),
+		kind: w.kind,
+		err:  l,
 	}
 }
 
+// push returns the chain of the error with its kind as the top most entry, the kind keeps its message and its original
+// error value, and is internal as the other entries added to a chain
+func (w wrapped) push() error {
+	l, ok := w.kind.err.(layer)
+	if !ok {
+		l = layer{orig: w.kind.err}
+	}
+	l.separator = w.kind.separator
+	l.next = w.err
+	return l
+}
+
+// Append joins the given errors with the error, returning a Grr that holds all of them as branches
+func (w wrapped) Append(errs ...error) Grr {
+	return Join(append([]error{w}, errs...)...)
+}
+
 // Grr implements the Wrapped interface
 func (w wrapped) Error() string {
 	if w.err == nil {
-		return w.kind.err.Error()
+		return w.kind.Error()
 	}
-	return w.kind.err.Error() + w.kind.separator + w.err.Error()
+	return w.kind.ext().redact.redacted(w.kind.err.Error() + w.kind.separator + w.err.Error() + w.kind.fieldsString())
 }
 
 // Is checks if the error is or contains the target error in its chain, therefore this function overrides the
-// default implementation of errors.Is
+// default implementation of errors.Is, errors are matched by identity unless string matching was enabled
 func (w wrapped) Is(target error) bool {
+	if target == nil {
+		return false
+	}
+	target, ok := codeTarget(target)
+	if !ok {
+		return false
+	}
+	if w.kind.match == matchString {
+		return w.isString(target)
+	}
+	switch t := target.(type) {
+	case kind:
+		target = t.err
+	case wrapped:
+		return t.Error() == w.Error() && errors.Is(w.kind.err, t.kind.err)
+	}
+	if errors.Is(w.kind.err, target) {
+		return true
+	}
+	return w.err != nil && errors.Is(w.err, target)
+}
+
+// isString checks if the error is or contains the target error in its chain by comparing their strings
+func (w wrapped) isString(target error) bool {
 	// check if the target is the same as the error
 	if g, ok := target.(Grr); ok {
 		if g.Error() == w.Error() {
@@ -90,10 +204,52 @@ func (w wrapped) Is(target error) bool {
 	return false
 }
 
+// As finds the first error in the kind that matches target, the remainder of the chain is walked by errors.As through
+// Unwrap
+func (w wrapped) As(target any) bool {
+	return errors.As(w.kind.err, target)
+}
+
 // Chain builds the error chain as a slice of error for the package type Wrapped, ordered with the last element being
-// the bottom most error in the chain
+// the bottom most error in the chain, the original error values are returned for the layers that have one, the errors
+// whose message is redacted still unwrap to the original errors
 func (w wrapped) Chain() []error {
-	return Chain(w, WithTrimCustom(w.kind.separator))
+	return w.kind.ext().redact.chain(Chain(w, WithTrimCustom(w.kind.separator)))
+}
+
+// Code returns the code the kind of the error was registered with, or an empty string if it was not registered
+func (w wrapped) Code() string {
+	return w.kind.Code()
+}
+
+// Fields returns the fields attached to the error
+func (w wrapped) Fields() []Field {
+	return copyFields(w.kind.ext().fields)
+}
+
+// StackTrace returns the stack trace captured when the error was created, when none was captured the stack trace of the
+// bottom most layer of the chain that has one is returned instead
+func (w wrapped) StackTrace() []runtime.Frame {
+	return w.origin().frames()
+}
+
+// origin returns the stack trace captured when the error was created, or the one of the bottom most layer that has one
+func (w wrapped) origin() *stack {
+	if w.kind.stack != nil {
+		return w.kind.stack
+	}
+	var s *stack
+	for l, ok := w.err.(layer); ok; l, ok = l.next.(layer) {
+		if l.stack != nil {
+			s = l.stack
+		}
+	}
+	return s
+}
+
+// Format implements the fmt.Formatter interface, the %+v verb includes the chain, the fields and the stack trace
+func (w wrapped) Format(s fmt.State, verb rune) {
+	format(s, verb, w, w.origin(), w.kind.ext().redact)
 }
 
 // Unwrap implements the Unwrap interface
@@ -38,7 +38,11 @@ func Test_wrapped_Add(t *testing.T) {
 					err:       errors.New("test"),
 					separator: _separator(),
 				},
-				err: fmt.Errorf("%v%s%w", errors.New("add"), _separator(), errors.New("wasThere")),
+				err: layer{
+					orig:      errors.New("add"),
+					separator: _separator(),
+					next:      errors.New("wasThere"),
+				},
 			},
 		},
 	}
@@ -178,6 +182,9 @@ func Test_wrapped_Is(t *testing.T) {
 		target error
 	}
 	nn := New(genWrappedErrorsWithSeparator(100)).(wrapped)
+	sentinel := errors.New("sentinel")
+	bottom := errors.New("bottom")
+	foreign := New(fmt.Errorf("top%s%w", _separator(), bottom)).(wrapped)
 	tests := []struct {
 		name   string
 		fields fields
@@ -190,6 +197,7 @@ func Test_wrapped_Is(t *testing.T) {
 				kind: kind{
 					err:       errors.New("test"),
 					separator: _separator(),
+					match:     matchString,
 				},
 				err: errors.New("original"),
 			},
@@ -204,6 +212,7 @@ func Test_wrapped_Is(t *testing.T) {
 				kind: kind{
 					err:       errors.New("test"),
 					separator: _separator(),
+					match:     matchString,
 				},
 				err: errors.New("original"),
 			},
@@ -218,6 +227,7 @@ func Test_wrapped_Is(t *testing.T) {
 				kind: kind{
 					err:       errors.New("test"),
 					separator: _separator(),
+					match:     matchString,
 				},
 				err: errors.New("original"),
 			},
@@ -232,6 +242,7 @@ func Test_wrapped_Is(t *testing.T) {
 				kind: kind{
 					err:       errors.New("test blablalfda sda sdf asf dad as dfas"),
 					separator: _separator(),
+					match:     matchString,
 				},
 				err: nil,
 			},
@@ -246,6 +257,7 @@ func Test_wrapped_Is(t *testing.T) {
 				kind: kind{
 					err:       nn.kind.err,
 					separator: nn.kind.separator,
+					match:     matchString,
 				},
 				err: nn.err,
 			},
@@ -260,6 +272,7 @@ func Test_wrapped_Is(t *testing.T) {
 				kind: kind{
 					err:       nn.kind.err,
 					separator: nn.kind.separator,
+					match:     matchString,
 				},
 				err: nn.err,
 			},
@@ -274,6 +287,7 @@ func Test_wrapped_Is(t *testing.T) {
 				kind: kind{
 					err:       nn.kind.err,
 					separator: nn.kind.separator,
+					match:     matchString,
 				},
 				err: nn.err,
 			},
@@ -282,6 +296,81 @@ func Test_wrapped_Is(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "IsIdentityKind",
+			fields: fields{
+				kind: kind{
+					err:       sentinel,
+					separator: _separator(),
+				},
+				err: errors.New("original"),
+			},
+			args: args{
+				target: sentinel,
+			},
+			want: true,
+		},
+		{
+			name: "IsIdentityChain",
+			fields: fields{
+				kind: kind{
+					err:       errors.New("test"),
+					separator: _separator(),
+				},
+				err: layer{
+					orig:      sentinel,
+					separator: _separator(),
+					next:      errors.New("original"),
+				},
+			},
+			args: args{
+				target: sentinel,
+			},
+			want: true,
+		},
+		{
+			name: "IsNotIdentitySameString",
+			fields: fields{
+				kind: kind{
+					err:       errors.New("sentinel"),
+					separator: _separator(),
+				},
+				err: errors.New("original"),
+			},
+			args: args{
+				target: sentinel,
+			},
+			want: false,
+		},
+		{
+			name: "IsIdentityForeignBottom",
+			fields: fields{
+				kind: foreign.kind,
+				err:  foreign.err,
+			},
+			args: args{
+				target: bottom,
+			},
+			want: true,
+		},
+		{
+			name: "IsIdentityKindTarget",
+			fields: fields{
+				kind: kind{
+					err:       errors.New("test"),
+					separator: _separator(),
+				},
+				err: layer{
+					orig:      sentinel,
+					separator: _separator(),
+					next:      errors.New("original"),
+				},
+			},
+			args: args{
+				target: New(sentinel),
+			},
+			want: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -349,7 +438,7 @@ func Test_wrapped_Sanitize(t *testing.T) {
 				kind: tt.fields.kind,
 				err:  tt.fields.err,
 			}
-			if got := w.Sanitize(); !reflect.DeepEqual(got, tt.want) {
+			if got := w.Sanitize(); !equalGrr(got, tt.want) {
 				t.Errorf("Sanitize() = %v, want %v", got, tt.want)
 			}
 		})
@@ -391,6 +480,58 @@ func Test_wrapped_Unwrap(t *testing.T) {
 	}
 }
 
+type exampleTypedError struct {
+	code int
+}
+
+func (e exampleTypedError) Error() string {
+	return fmt.Sprint("typed error ", e.code)
+}
+
+func Test_wrapped_As(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      Grr
+		wantCode int
+		want     bool
+	}{
+		{
+			name:     "AsKind",
+			err:      New(exampleTypedError{code: 1}).Add(errors.New("added")),
+			wantCode: 1,
+			want:     true,
+		},
+		{
+			name:     "AsChain",
+			err:      New(errors.New("test")).Add(exampleTypedError{code: 2}).Add(errors.New("added")),
+			wantCode: 2,
+			want:     true,
+		},
+		{
+			name:     "AsForeignChain",
+			err:      New(fmt.Errorf("top%s%w", _separator(), exampleTypedError{code: 3})),
+			wantCode: 3,
+			want:     true,
+		},
+		{
+			name: "AsNotFound",
+			err:  New(errors.New("test")).Add(errors.New("added")),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var target exampleTypedError
+			if got := errors.As(tt.err, &target); got != tt.want {
+				t.Errorf("errors.As() = %v, want %v", got, tt.want)
+			}
+			if target.code != tt.wantCode {
+				t.Errorf("errors.As() code = %v, want %v", target.code, tt.wantCode)
+			}
+		})
+	}
+}
+
 func genWrappedErrorsWithSeparator(count int) error {
 	return genWrappedErrWith(count, _separator(), "")
 }